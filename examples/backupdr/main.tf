@@ -62,48 +62,21 @@ data "backupdr_template" "example" {
 resource "backupdr_template" "name" {
   name        = "test"
   description = "test template"
-  policies = [
-    # {
-    # "op"             = "cloud",
-    # "name"           = "onvault-pol",
-    # "iscontinuous"   = false,
-    # "starttime"      = 68400,
-    # "endtime"        = 67800,
-    # "rpo"            = "24",
-    # "rpom"           = "hours",
-    # "retention"      = "14",
-    # "retentionm"     = "days",
-    # "priority"       = "medium",
-    # "sourcevault"    = 0,
-    # "targetvault"    = 1,
-    # "temp-id"        = "431",
-    # "scheduletype"   = "daily",
-    # "repeatinterval" = "1",
-    # # "scheduling"        = "continous",
-    # "exclusiontype"     = "none",
-    # "exclusioninterval" = "1",
-    # "exclusion"         = "none",
-    # "selection"         = "none"
-    # }, 
-    {
-      endtime           = 25200,
-      exclusion         = "none",
-      exclusioninterval = "1",
-      exclusiontype     = "none",
-      iscontinuous      = false,
-      name              = "pol1",
-      op                = "snap",
-      priority          = "medium",
-      repeatinterval    = "1",
-      retention         = "2",
-      retentionm        = "days",
-      rpo               = "24",
-      rpom              = "hours",
-      scheduletype      = "daily",
-      policytype        = "windowed",
-      selection         = "none",
-      starttime         = 68400,
-  }]
+
+  snapshot_policy {
+    name       = "pol1"
+    start_time = "19:00"
+    end_time   = "07:00"
+    retention  = 2
+  }
+
+  onvault_policy {
+    name       = "onvault-pol"
+    start_time = "19:00"
+    end_time   = "18:50"
+    retention  = 14
+    pool       = 1
+  }
 }
 
 #####  Appliance  #####################
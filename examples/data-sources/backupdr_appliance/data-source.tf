@@ -1,4 +1,9 @@
 data "backupdr_appliance" "example" {
   ## replace with appliance ID
   id = "86122"
-}
\ No newline at end of file
+}
+
+## or look the appliance up by name
+data "backupdr_appliance" "by_name" {
+  name = "bkpdr-appliance-1"
+}
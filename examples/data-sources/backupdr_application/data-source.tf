@@ -0,0 +1,4 @@
+data "backupdr_application" "example" {
+  apptype    = "GCPInstance"
+  uniquename = "<gcp-vm-instanceid>"
+}
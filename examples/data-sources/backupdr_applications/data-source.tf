@@ -0,0 +1,6 @@
+## list the unprotected GCE VMs discovered by an appliance
+data "backupdr_applications" "example" {
+  apptype   = "GCPInstance"
+  appliance = "144292692833"
+  managed   = false
+}
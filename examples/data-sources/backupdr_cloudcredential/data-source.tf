@@ -2,3 +2,8 @@ data "backupdr_cloudcredential" "example" {
   ## replace with cloud credential ID
   id = "49385"
 }
+
+## or look the cloud credential up by name
+data "backupdr_cloudcredential" "by_name" {
+  name = "prod-credential"
+}
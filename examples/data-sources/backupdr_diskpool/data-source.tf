@@ -1,4 +1,10 @@
 data "backupdr_diskpool" "example" {
   ## Replace with any existing diskpool ID 
   id = "62448"
-}
\ No newline at end of file
+}
+
+## or look the diskpool up by name on an appliance
+data "backupdr_diskpool" "by_name" {
+  name                = "onvault-pool"
+  appliance_clusterid = "144292692833"
+}
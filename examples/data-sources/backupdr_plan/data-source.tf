@@ -1,4 +1,9 @@
 data "backupdr_plan" "example" {
   ## Replace with any existing SLA Plan ID 
   id = "64274"
-}
\ No newline at end of file
+}
+
+## or look the SLA Plan up by the name of the application it protects
+data "backupdr_plan" "by_appname" {
+  appname = "web-1"
+}
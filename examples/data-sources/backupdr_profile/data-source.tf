@@ -1,4 +1,10 @@
 data "backupdr_profile" "example" {
   ## Replace with any existing Profile ID 
   id = "21032"
-}
\ No newline at end of file
+}
+
+## or look the Profile up by name on an appliance
+data "backupdr_profile" "by_name" {
+  name      = "us-central1-profile"
+  clusterid = "144292692833"
+}
@@ -1,4 +1,9 @@
 data "backupdr_template" "example" {
   ## Replace with any existing SLA Template ID 
   id = "63512"
-}
\ No newline at end of file
+}
+
+## or look the SLA Template up by name
+data "backupdr_template" "by_name" {
+  name = "daily-snapshots"
+}
@@ -1,15 +1,14 @@
-## Configure google provider
+## Configure the provider with Application Default Credentials.
 ## Make sure to intialise `gcloud auth application-default login`
-provider "google" {
-  project = "<project-id>"
-  region  = "<region>"
-}
-
-## Use client config datasource to fetch access_token
-data "google_client_config" "default" {}
-
 provider "backupdr" {
   ## Replace with Backup DR Service Endpoint
-  endpoint     = "https://bmc-30000000000-xxxxxxxxx-dot-us-central1.backupdr.googleusercontent.com"
-  access_token = data.google_client_config.default.access_token
+  endpoint = "https://bmc-30000000000-xxxxxxxxx-dot-us-central1.backupdr.googleusercontent.com"
 }
+
+## Alternatively, use a service account key and impersonate another service account.
+## Tokens are refreshed automatically for the whole run.
+# provider "backupdr" {
+#   endpoint                    = "https://bmc-30000000000-xxxxxxxxx-dot-us-central1.backupdr.googleusercontent.com"
+#   credentials                 = file("sa-key.json")
+#   impersonate_service_account = "backupdr-admin@<project-id>.iam.gserviceaccount.com"
+# }
@@ -0,0 +1,3 @@
+# Cloud VM applications can be imported by cloud credential ID, appliance cluster ID,
+# project ID, region and a comma separated list of VM instance IDs.
+terraform import backupdr_application_compute_vm.example <cloud-credential-id>/<appliance-clusterid>/<gcp-project>/<gcp-region>/<gcp-vm-instanceid-1>,<gcp-vm-instanceid-2>
@@ -0,0 +1,3 @@
+# vCenter VM applications can be imported by appliance ID, vCenter host ID, cluster name
+# and a comma separated list of VM UUIDs.
+terraform import backupdr_application_vmware_vm.name <appliance-id>/<vcenter-host-id>/<vcenter-cluster-name>/<vcenter-vm-uuid-1>,<vcenter-vm-uuid-2>
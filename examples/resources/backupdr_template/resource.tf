@@ -1,9 +1,38 @@
 resource "backupdr_template" "example" {
   name        = "<name>"
   description = "<SLA Template description>"
-  policies = [
-    {
-      name              = "<policy name>",
+
+  options = {
+    appconsistent = "true"
+  }
+
+  snapshot_policy {
+    name       = "snapshot-policy"
+    start_time = "19:00"
+    end_time   = "07:00"
+    retention  = 2
+    options = {
+      truncatelog = "true"
+    }
+  }
+
+  onvault_policy {
+    name       = "onvault-policy"
+    start_time = "19:00"
+    end_time   = "18:50"
+    retention  = 14
+    pool       = 1
+  }
+}
+
+# The raw policies cover the settings the typed blocks do not. Times, RPOs,
+# retentions and days can be given in the console encoding or in the human
+# forms used by the snapshot-policy.
+resource "backupdr_template" "advanced" {
+  name        = "<name>"
+  description = "<SLA Template description>"
+  policies = {
+    "onvault-policy" = {
       op                = "cloud",
       iscontinuous      = false,
       starttime         = 68400,
@@ -22,22 +51,20 @@ resource "backupdr_template" "example" {
       exclusion         = "none",
       selection         = "none"
     },
-    {
-      name              = "<policy name>",
+    "snapshot-policy" = {
       op                = "snap",
-      endtime           = 25200,
+      start_time        = "19:00",
+      end_time          = "07:00",
       exclusion         = "none",
       exclusioninterval = "1",
       exclusiontype     = "none",
       iscontinuous      = false,
       priority          = "medium",
       repeatinterval    = "1",
-      retention         = "2",
-      retentionm        = "days",
-      rpo               = "24",
-      rpom              = "hours",
-      scheduletype      = "daily",
-      selection         = "none",
-      starttime         = 68400,
-  }]
+      retention         = "2d",
+      rpo               = "24h",
+      scheduletype      = "weekly",
+      days_of_week      = ["sat", "sun"],
+    }
+  }
 }
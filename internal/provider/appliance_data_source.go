@@ -2,12 +2,15 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 
+	"github.com/antihax/optional"
 	backupdr "github.com/umeshkumhar/backupdr-client"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -68,8 +71,9 @@ func (d *applianceDataSource) Schema(ctx context.Context, req datasource.SchemaR
 		MarkdownDescription: "This data source can be used to read information about a backup/recovery Appliance. It displays the backup/recovery appliance ID as shown in the **Management console** > **Manage** > **Appliances** page.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "Provide the ID of the appliance.",
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Provide the ID of the appliance. Either `id` or `name` must be set.",
 			},
 			"href": schema.StringAttribute{
 				Computed:            true,
@@ -104,8 +108,9 @@ func (d *applianceDataSource) Schema(ctx context.Context, req datasource.SchemaR
 				MarkdownDescription: "It displays the version of the backup appliance.",
 			},
 			"name": schema.StringAttribute{
+				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "It displays the name of the backup/recovery appliance ID.",
+				MarkdownDescription: "Provide the name of the backup/recovery appliance to look it up instead of `id`. It displays the name of the backup/recovery appliance ID.",
 			},
 			"type": schema.StringAttribute{
 				Computed:            true,
@@ -137,19 +142,35 @@ func (d *applianceDataSource) Schema(ctx context.Context, req datasource.SchemaR
 
 func (d *applianceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 
+	if !checkProviderConfigured(d.client, &resp.Diagnostics) {
+		return
+	}
+
 	var state appliancesResourceModel
 	// Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if !checkLookupKeys(state.ID, state.Name, "name", &resp.Diagnostics) {
+		return
+	}
+
+	id := state.ID.ValueString()
+	if id == "" {
+		var ok bool
+		if id, ok = d.lookupID(state, &resp.Diagnostics); !ok {
+			return
+		}
+	}
 
-	applianceID, convErr := strconv.ParseInt(state.ID.ValueString(), 10, 64)
+	applianceID, convErr := strconv.ParseInt(id, 10, 64)
 	if convErr != nil {
 		tflog.Error(ctx, "Error parsing appliance ID - "+convErr.Error())
 	}
 	appliance, res, err := d.client.ApplianceApi.GetCluster(d.authCtx, applianceID, nil)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addConsoleError(&resp.Diagnostics,
 			"Unable to Read BackupDR Appliance",
-			err.Error(),
+			"Could not read appliance with ID "+id+".",
+			res, err, nil,
 		)
 		return
 	}
@@ -187,3 +208,28 @@ func (d *applianceDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 }
+
+// lookupID resolves the appliance ID from its name.
+func (d *applianceDataSource) lookupID(state appliancesResourceModel, diags *diag.Diagnostics) (string, bool) {
+	name := state.Name.ValueString()
+	filter := backupdr.ApplianceApiListClustersOpts{
+		Filter: optional.NewString("name:==" + name),
+	}
+	appliances, res, err := d.client.ApplianceApi.ListClusters(d.authCtx, &filter)
+	if err != nil {
+		addConsoleError(diags,
+			"Unable to Read BackupDR Appliance",
+			"Could not list appliances named "+name+".",
+			res, err, nil,
+		)
+		return "", false
+	}
+
+	var candidates []lookupCandidate
+	for _, appliance := range appliances.Items {
+		if appliance.Name == name {
+			candidates = append(candidates, lookupCandidate{ID: appliance.Id, Name: appliance.Name})
+		}
+	}
+	return resolveLookupID("Appliance", fmt.Sprintf("name %q", name), candidates, diags)
+}
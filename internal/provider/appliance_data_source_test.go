@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccApplianceDataSource(t *testing.T) {
+	console := newFakeConsole(t)
+	applianceID := console.Seed("cluster", map[string]any{
+		"clusterid": "144292692833",
+		"name":      "backup-appliance-1",
+		"ipaddress": "10.0.0.2",
+		"region":    "us-central1",
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(console) + `
+data "backupdr_appliance" "by_id" {
+  id = "` + applianceID + `"
+}
+
+data "backupdr_appliance" "by_name" {
+  name = "backup-appliance-1"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backupdr_appliance.by_id", "clusterid", "144292692833"),
+					resource.TestCheckResourceAttr("data.backupdr_appliance.by_id", "ipaddress", "10.0.0.2"),
+					resource.TestCheckResourceAttr("data.backupdr_appliance.by_name", "id", applianceID),
+				),
+			},
+		},
+	})
+}
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 
+	"github.com/antihax/optional"
 	backupdr "github.com/umeshkumhar/backupdr-client"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -24,7 +25,10 @@ type applianceAllDataSource struct {
 
 // tf go model
 type allAppliancesResourceModel struct {
-	Items []appliancesResourceModel `tfsdk:"items"`
+	Limit  types.Int64               `tfsdk:"limit"`
+	Sort   types.String              `tfsdk:"sort"`
+	Filter types.String              `tfsdk:"filter"`
+	Items  []appliancesResourceModel `tfsdk:"items"`
 }
 
 // NewApplianceAllDataSource - Datasource for SLA Profile
@@ -49,7 +53,7 @@ func (d *applianceAllDataSource) Metadata(ctx context.Context, req datasource.Me
 func (d *applianceAllDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "This data source can be used to read information about all backup/recovery Appliances. It displays the backup/recovery appliance ID as shown in the **Management console** > **Manage** > **Appliances** page.",
-		Attributes: map[string]schema.Attribute{
+		Attributes: listAttributes("appliances", map[string]schema.Attribute{
 			"items": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -121,35 +125,51 @@ func (d *applianceAllDataSource) Schema(ctx context.Context, req datasource.Sche
 					},
 				},
 			},
-		},
+		}),
 	}
 }
 
 func (d *applianceAllDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 
+	if !checkProviderConfigured(d.client, &resp.Diagnostics) {
+		return
+	}
+
 	var state allAppliancesResourceModel
 	// Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
-
-	appliances, res, err := d.client.ApplianceApi.ListClusters(d.authCtx, nil)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Read BackupDR Appliances",
-			err.Error(),
-		)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	opts, ok := newListOptions(state.Limit, state.Sort, state.Filter, &resp.Diagnostics)
+	if !ok {
 		return
 	}
 
-	if res.StatusCode != 200 {
-		resp.Diagnostics.AddError(
+	appliances, err := listAll(opts.Limit, func(limit, offset int64) ([]backupdr.ClusterRest, int32, error) {
+		page, res, err := d.client.ApplianceApi.ListClusters(d.authCtx, &backupdr.ApplianceApiListClustersOpts{
+			Sort:   optionalString(opts.Sort),
+			Filter: optionalString(opts.Filter),
+			Limit:  optional.NewInt64(limit),
+			Offset: optional.NewInt64(offset),
+		})
+		if err != nil {
+			return nil, 0, newRequestError(res, err)
+		}
+		return page.Items, page.Count, nil
+	}, nil)
+	if err != nil {
+		addConsoleError(&resp.Diagnostics,
 			"Unable to Read BackupDR Appliances",
-			res.Status,
+			"Could not list appliances.",
+			nil, err, nil,
 		)
+		return
 	}
 
 	var apps = []appliancesResourceModel{}
 	// Map response body to model
-	for _, appliance := range appliances.Items {
+	for _, appliance := range appliances {
 		applianceState := appliancesResourceModel{
 			ID:              types.StringValue(appliance.Id),
 			Href:            types.StringValue(appliance.Href),
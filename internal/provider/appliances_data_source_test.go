@@ -0,0 +1,30 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAppliancesDataSource(t *testing.T) {
+	console := newFakeConsole(t)
+	console.Seed("cluster", map[string]any{"clusterid": "144292692833", "name": "backup-appliance-1"})
+	console.Seed("cluster", map[string]any{"clusterid": "144292692834", "name": "backup-appliance-2"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(console) + `
+data "backupdr_appliances" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backupdr_appliances.test", "items.#", "2"),
+					resource.TestCheckResourceAttr("data.backupdr_appliances.test", "items.0.name", "backup-appliance-1"),
+					resource.TestCheckResourceAttr("data.backupdr_appliances.test", "items.1.clusterid", "144292692834"),
+				),
+			},
+		},
+	})
+}
@@ -3,24 +3,42 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/antihax/optional"
 	backupdr "github.com/umeshkumhar/backupdr-client"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &applicationComputeVMsResource{}
-	_ resource.ResourceWithConfigure   = &applicationComputeVMsResource{}
-	_ resource.ResourceWithImportState = &applicationComputeVMsResource{}
+	_ resource.Resource                 = &applicationComputeVMsResource{}
+	_ resource.ResourceWithConfigure    = &applicationComputeVMsResource{}
+	_ resource.ResourceWithImportState  = &applicationComputeVMsResource{}
+	_ resource.ResourceWithUpgradeState = &applicationComputeVMsResource{}
 )
 
+// applicationComputeVMConsoleFields maps the fields of Cloud VM discovery requests to the attributes
+// they are set from, so console errors are reported against them.
+var applicationComputeVMConsoleFields = map[string]path.Path{
+	"region":    path.Root("region"),
+	"projectid": path.Root("projectid"),
+	"cluster":   path.Root("appliance_clusterid"),
+	"vmids":     path.Root("vmids"),
+}
+
 // NewApplicationComputeVMsResource to create vCenter Host
 func NewApplicationComputeVMsResource() resource.Resource {
 	return &applicationComputeVMsResource{}
@@ -30,17 +48,23 @@ func NewApplicationComputeVMsResource() resource.Resource {
 type applicationComputeVMsResource struct {
 	client  *backupdr.APIClient
 	authCtx context.Context
+	// applications is the application index shared by the resources of
+	// the provider instance.
+	applications *applicationIndex
 }
 
 // tf go model
 type applicationComputeVMsResourceModel struct {
+	ID                 types.String   `tfsdk:"id"`
 	CloudCredential    types.String   `tfsdk:"cloudcredential"`
 	ApplianceClusterID types.String   `tfsdk:"appliance_clusterid"`
 	VMIds              []types.String `tfsdk:"vmids"`
 	Region             types.String   `tfsdk:"region"`
 	ProjectID          types.String   `tfsdk:"projectid"`
 	Status             types.String   `tfsdk:"status"`
-	Applications       types.List     `tfsdk:"applications"`
+	DiscoveryTimeout   types.String   `tfsdk:"discovery_timeout"`
+	Applications       types.Map      `tfsdk:"applications"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
 }
 
 // Metadata returns the resource type name.
@@ -49,41 +73,70 @@ func (r *applicationComputeVMsResource) Metadata(_ context.Context, req resource
 }
 
 // Schema defines the schema for the resource.
-func (r *applicationComputeVMsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *applicationComputeVMsResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:             1,
 		MarkdownDescription: "You can use this resource to onboard GCE VMs as an application into the Backup and DR Service. After you onboard the application, you can perform backup or restore operations.",
 		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "It displays the resource ID in the format `cloudcredential/appliance_clusterid/projectid/region`.",
+			},
 			"cloudcredential": schema.StringAttribute{
-				Required:            true,
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 				MarkdownDescription: "Provide the ID of the cloud credential.",
 			},
 			"appliance_clusterid": schema.StringAttribute{
-				Required:            true,
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 				MarkdownDescription: "Provide the ID of the backup/recovery appliance.",
 			},
 			"projectid": schema.StringAttribute{
-				Required:            true,
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 				MarkdownDescription: "Provide the ID of the project in which the resource belongs. If it is not provided, the provider project is used.",
 			},
 			"region": schema.StringAttribute{
-				Required:            true,
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 				MarkdownDescription: "Provide the region to create the cloud credential.",
 			},
-			"vmids": schema.ListAttribute{
+			"vmids": schema.SetAttribute{
 				Required:            true,
 				ElementType:         types.StringType,
-				MarkdownDescription: "Provide the list of GCP instance IDs.",
+				MarkdownDescription: "Provide the set of GCP instance IDs. Adding or removing an ID only registers or unregisters that VM.",
 			},
 			"status": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "It displays the status of the request.",
 			},
-			"applications": schema.ListAttribute{
+			"discovery_timeout": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultDiscoveryTimeout),
+				MarkdownDescription: "How long to wait for the appliance to register newly added VMs as applications, such as `30s` or `10m`. Defaults to `5m`.",
+			},
+			"applications": schema.MapAttribute{
 				Computed:            true,
 				ElementType:         types.StringType,
-				MarkdownDescription: "It displays the list of Application IDs.",
+				MarkdownDescription: "It displays the application IDs keyed by GCP instance ID.",
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": resourceTimeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -95,10 +148,15 @@ func (r *applicationComputeVMsResource) Configure(_ context.Context, req resourc
 
 	r.client = req.ProviderData.(*backupdrProvider).client
 	r.authCtx = req.ProviderData.(*backupdrProvider).authCtx
+	r.applications = req.ProviderData.(*backupdrProvider).applications
 }
 
 // Create a new resource.
 func (r *applicationComputeVMsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !checkProviderConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	// Retrieve values from plan
 	var plan applicationComputeVMsResourceModel
 	var listVMs []string
@@ -108,172 +166,201 @@ func (r *applicationComputeVMsResource) Create(ctx context.Context, req resource
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, authCtx, cancel := withTimeout(ctx, r.authCtx, createTimeout)
+	defer cancel()
+
 	for _, vm := range plan.VMIds {
 		listVMs = append(listVMs, vm.ValueString())
 	}
 
-	reqCloudAddVMs := backupdr.CloudVmDiscoveryRest{
-		Region:    plan.Region.ValueString(),
-		ProjectId: plan.ProjectID.ValueString(),
-		Vmids:     listVMs,
-		ListOnly:  false,
+	discoveryTimeout := parseDurationAttribute(path.Root("discovery_timeout"), plan.DiscoveryTimeout.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	reqCloudAddVMs.Cluster = &backupdr.ClusterRest{Clusterid: plan.ApplianceClusterID.ValueString()}
 
-	// Generate API request body from plan
-	reqBody := backupdr.DefaultApiAddVmOpts{
-		Body: optional.NewInterface(reqCloudAddVMs),
+	status, ok := r.addVMs(authCtx, plan, listVMs, &resp.Diagnostics)
+	if !ok {
+		return
 	}
+	plan.Status = types.StringValue(status)
 
-	// Add new Cloud VMs
-	respObject, err := r.client.DefaultApi.AddVm(r.authCtx, plan.CloudCredential.ValueString(), &reqBody)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error adding Cloud VM",
-			"Could not add Cloud VMs, unexpected error: "+err.Error(),
-		)
+	// Unresolved VMs fail the apply. The VMs that were resolved are still
+	// saved, which leaves the resource tainted so it is replaced next time.
+	applicationIDs := make(map[string]attr.Value, len(listVMs))
+	resolveApplications(ctx, r.applications, r.client, authCtx, listVMs, discoveryTimeout, applicationIDs, &resp.Diagnostics)
+
+	plan.Applications, diags = types.MapValue(types.StringType, applicationIDs)
+	resp.Diagnostics.Append(diags...)
+
+	plan.ID = types.StringValue(strings.Join([]string{
+		plan.CloudCredential.ValueString(),
+		plan.ApplianceClusterID.ValueString(),
+		plan.ProjectID.ValueString(),
+		plan.Region.ValueString(),
+	}, "/"))
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+}
 
-	if respObject.StatusCode == 200 || respObject.StatusCode == 204 {
-		// Map response body to schema and populate Computed attribute values
-		plan.Status = types.StringValue(respObject.Status)
-		applicationIDs := make([]attr.Value, 0)
+// Read resource information.
+func (r *applicationComputeVMsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if unknownProviderConfig(ctx, r.client) {
+		return
+	}
 
-		for _, application := range listVMs {
+	// Get current state
+	var state applicationComputeVMsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-			filter := backupdr.ApplicationApiListApplicationsOpts{
-				Filter: optional.NewString(fmt.Sprintf("uniquename:==%s", application)),
-			}
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, authCtx, cancel := withTimeout(ctx, r.authCtx, readTimeout)
+	defer cancel()
+
+	// Keep only the VMs that are still registered as applications, so the
+	// missing ones are planned to be added again.
+	names := make([]string, 0, len(state.VMIds))
+	for _, vm := range state.VMIds {
+		names = append(names, vm.ValueString())
+	}
+	applications, err := r.applications.Resolve(r.client, authCtx, names, time.Time{})
+	if err != nil {
+		addConsoleError(&resp.Diagnostics,
+			"Error Reading Cloud VM Applications",
+			"Could not read the applications of the Cloud VMs.",
+			nil, err, nil,
+		)
+		return
+	}
 
-			// fetch application ID with filter
-			lsApps, res, err := r.client.ApplicationApi.ListApplications(r.authCtx, &filter)
-			if err != nil {
-				resp.Diagnostics.AddError(
-					"Error listing applications",
-					"Could not list applications, unexpected error: "+res.Status+" : "+err.Error(),
-				)
-				return
-			}
-			// capture the id of filtered application
-			if lsApps.Items != nil && len(lsApps.Items) > 0 {
-				applicationIDs = append(applicationIDs, types.StringValue(lsApps.Items[0].Id))
-			} else {
-				applicationIDs = append(applicationIDs, types.StringValue("unknown-error"))
-			}
-		}
-		if len(applicationIDs) > 0 {
-			plan.Applications, diags = types.ListValue(types.StringType, applicationIDs)
-			resp.Diagnostics.Append(diags...)
-			if resp.Diagnostics.HasError() {
-				return
-			}
+	vmIDs := make([]types.String, 0, len(state.VMIds))
+	applicationIDs := make(map[string]attr.Value, len(state.VMIds))
+	for _, vm := range state.VMIds {
+		application, ok := applications[vm.ValueString()]
+		if !ok {
+			tflog.Warn(ctx, "Cloud VM is no longer registered as an application, removing it from state", map[string]any{"vmid": vm.ValueString()})
+			continue
 		}
+		vmIDs = append(vmIDs, vm)
+		applicationIDs[vm.ValueString()] = types.StringValue(application.Id)
+	}
 
-	} else {
-		plan.Status = types.StringValue(respObject.Status)
-		resp.Diagnostics.AddError(
-			"Error adding Cloud VM",
-			"Could not add Cloud VMs, unexpected error: "+err.Error(),
-		)
+	if len(vmIDs) == 0 {
+		resp.State.RemoveResource(ctx)
 		return
 	}
 
-	// Set state to fully populated data
-	diags = resp.State.Set(ctx, plan)
+	state.VMIds = vmIDs
+	state.Applications, diags = types.MapValue(types.StringType, applicationIDs)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-}
 
-// Read resource information.
-func (r *applicationComputeVMsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	// Does not support Read
-	return
+	// State upgraded from version 0 predates discovery_timeout
+	if state.DiscoveryTimeout.IsNull() {
+		state.DiscoveryTimeout = types.StringValue(defaultDiscoveryTimeout)
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 }
 
 func (r *applicationComputeVMsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !checkProviderConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	// Retrieve values from plan
 	var plan applicationComputeVMsResourceModel
-	var listVMs []string
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	for _, vm := range plan.VMIds {
-		listVMs = append(listVMs, vm.ValueString())
-	}
-
-	reqCloudAddVMs := backupdr.CloudVmDiscoveryRest{
-		Region:    plan.Region.ValueString(),
-		ProjectId: plan.ProjectID.ValueString(),
-		Vmids:     listVMs,
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	reqCloudAddVMs.Cluster = &backupdr.ClusterRest{Clusterid: plan.ApplianceClusterID.ValueString()}
+	ctx, authCtx, cancel := withTimeout(ctx, r.authCtx, updateTimeout)
+	defer cancel()
 
-	// Generate API request body from plan
-	reqBody := backupdr.DefaultApiAddVmOpts{
-		Body: optional.NewInterface(reqCloudAddVMs),
+	// Get current state
+	var state applicationComputeVMsResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	// Add new Cloud VMs
-	respObject, err := r.client.DefaultApi.AddVm(r.authCtx, plan.CloudCredential.ValueString(), &reqBody)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error updating Cloud VM",
-			"Could not update Cloud VMs, unexpected error: "+err.Error(),
-		)
+	discoveryTimeout := parseDurationAttribute(path.Root("discovery_timeout"), plan.DiscoveryTimeout.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if respObject.StatusCode == 200 || respObject.StatusCode == 204 {
-		// Map response body to schema and populate Computed attribute values
-		plan.Status = types.StringValue(respObject.Status)
-		applicationIDs := make([]attr.Value, 0)
-		applicationIDs = append(applicationIDs, plan.Applications.Elements()...)
-
-		for _, application := range listVMs {
+	addedVMs, removedVMs := diffVMs(state.VMIds, plan.VMIds)
+	applicationIDs := state.Applications.Elements()
 
-			filter := backupdr.ApplicationApiListApplicationsOpts{
-				Filter: optional.NewString(fmt.Sprintf("uniquename:==%s", application)),
-			}
+	// Unregister the VMs removed from the set
+	for _, vm := range removedVMs {
+		application, ok := applicationIDs[vm]
+		if !ok {
+			continue
+		}
+		if !r.deleteApplication(authCtx, vm, application.(types.String).ValueString(), &resp.Diagnostics) {
+			return
+		}
+		delete(applicationIDs, vm)
+	}
 
-			// fetch application ID with filter
-			lsApps, res, err := r.client.ApplicationApi.ListApplications(r.authCtx, &filter)
-			if err != nil {
-				resp.Diagnostics.AddError(
-					"Error listing applications",
-					"Could not list applications, unexpected error: "+res.Status+" : "+err.Error(),
-				)
-				return
-			}
-			// capture the id of filtered application
-			if lsApps.Items != nil && len(lsApps.Items) > 0 {
-				applicationIDs = append(applicationIDs, types.StringValue(lsApps.Items[0].Id))
-			} else {
-				applicationIDs = append(applicationIDs, types.StringValue("unknown-error"))
-			}
+	// Discover only the VMs added to the set
+	plan.Status = state.Status
+	if len(addedVMs) > 0 {
+		status, ok := r.addVMs(authCtx, plan, addedVMs, &resp.Diagnostics)
+		if !ok {
+			return
 		}
-		if len(applicationIDs) > 0 {
-			plan.Applications, diags = types.ListValue(types.StringType, applicationIDs)
-			resp.Diagnostics.Append(diags...)
-			if resp.Diagnostics.HasError() {
-				return
+		plan.Status = types.StringValue(status)
+
+		// Keep the unresolved VMs out of state so they are added again on
+		// the next apply.
+		if !resolveApplications(ctx, r.applications, r.client, authCtx, addedVMs, discoveryTimeout, applicationIDs, &resp.Diagnostics) {
+			resolvedVMs := make([]types.String, 0, len(plan.VMIds))
+			for _, vm := range plan.VMIds {
+				if _, ok := applicationIDs[vm.ValueString()]; ok {
+					resolvedVMs = append(resolvedVMs, vm)
+				}
 			}
+			plan.VMIds = resolvedVMs
 		}
-
-	} else {
-		plan.Status = types.StringValue(respObject.Status)
-		resp.Diagnostics.AddError(
-			"Error updating Cloud VM",
-			"Could not update Cloud VMs, unexpected error: "+err.Error(),
-		)
-		return
 	}
 
+	plan.Applications, diags = types.MapValue(types.StringType, applicationIDs)
+	resp.Diagnostics.Append(diags...)
+
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -282,11 +369,130 @@ func (r *applicationComputeVMsResource) Update(ctx context.Context, req resource
 }
 
 func (r *applicationComputeVMsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	// Does not support Deletion of VM.. TODO. implement Application deletion.
-	return
+	if !checkProviderConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	// Retrieve values from state
+	var state applicationComputeVMsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, authCtx, cancel := withTimeout(ctx, r.authCtx, deleteTimeout)
+	defer cancel()
+
+	// Unregister the applications added by this resource
+	for vm, application := range state.Applications.Elements() {
+		if !r.deleteApplication(authCtx, vm, application.(types.String).ValueString(), &resp.Diagnostics) {
+			return
+		}
+	}
 }
 
+// ImportState imports Cloud VMs with an ID in the format
+// cloudcredential/appliance_clusterid/projectid/region/vmid1,vmid2.
 func (r *applicationComputeVMsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	if !checkProviderConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 5 || parts[0] == "" || parts[1] == "" || parts[2] == "" || parts[3] == "" || parts[4] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: cloudcredential/appliance_clusterid/projectid/region/vmid1,vmid2. Got: %q", req.ID),
+		)
+		return
+	}
+
+	vmIDs := make([]types.String, 0)
+	for _, vm := range strings.Split(parts[4], ",") {
+		if vm = strings.TrimSpace(vm); vm != "" {
+			vmIDs = append(vmIDs, types.StringValue(vm))
+		}
+	}
+
+	state := applicationComputeVMsResourceModel{
+		ID:                 types.StringValue(strings.Join(parts[:4], "/")),
+		CloudCredential:    types.StringValue(parts[0]),
+		ApplianceClusterID: types.StringValue(parts[1]),
+		ProjectID:          types.StringValue(parts[2]),
+		Region:             types.StringValue(parts[3]),
+		VMIds:              vmIDs,
+		Status:             types.StringNull(),
+		DiscoveryTimeout:   types.StringValue(defaultDiscoveryTimeout),
+		Applications:       types.MapNull(types.StringType),
+		Timeouts:           nullTimeouts(),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// UpgradeState converts the positional applications list of version 0 into
+// a map keyed by VM ID.
+func (r *applicationComputeVMsResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: upgradeApplicationsToMap("vmids"),
+	}
+}
+
+// addVMs asks the appliance to discover and register the given Cloud VMs and
+// returns the status of the request.
+func (r *applicationComputeVMsResource) addVMs(authCtx context.Context, plan applicationComputeVMsResourceModel, vmIDs []string, diags *diag.Diagnostics) (string, bool) {
+	reqCloudAddVMs := backupdr.CloudVmDiscoveryRest{
+		Region:    plan.Region.ValueString(),
+		ProjectId: plan.ProjectID.ValueString(),
+		Vmids:     vmIDs,
+		ListOnly:  false,
+	}
+	reqCloudAddVMs.Cluster = &backupdr.ClusterRest{Clusterid: plan.ApplianceClusterID.ValueString()}
+
+	// Generate API request body from plan
+	reqBody := backupdr.DefaultApiAddVmOpts{
+		Body: optional.NewInterface(reqCloudAddVMs),
+	}
+
+	// Add new Cloud VMs
+	respObject, err := r.client.DefaultApi.AddVm(authCtx, plan.CloudCredential.ValueString(), &reqBody)
+	if err != nil {
+		addConsoleError(diags,
+			"Error adding Cloud VM",
+			"Could not add Cloud VMs.",
+			respObject, err, applicationComputeVMConsoleFields,
+		)
+		return "", false
+	}
+
+	if respObject.StatusCode != 200 && respObject.StatusCode != 204 {
+		diags.AddError(
+			"Error adding Cloud VM",
+			"Could not add Cloud VMs, unexpected status: "+respObject.Status,
+		)
+		return "", false
+	}
+
+	return respObject.Status, true
+}
+
+// deleteApplication unregisters the application of a Cloud VM. An application
+// already removed in the console counts as unregistered.
+func (r *applicationComputeVMsResource) deleteApplication(authCtx context.Context, vm, applicationID string, diags *diag.Diagnostics) bool {
+	res, err := r.client.ApplicationApi.DeleteApplication(authCtx, applicationID)
+	if err != nil && !isNotFound(res, err) {
+		addConsoleError(diags,
+			"Error Deleting Cloud VM Application",
+			"Could not delete application "+applicationID+" of Cloud VM "+vm+".",
+			res, err, nil,
+		)
+		return false
+	}
+	return true
 }
@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func testAccApplicationComputeVMResourceConfig(console *fakeConsole, vmIDs ...string) string {
+	return testAccProviderConfig(console) + fmt.Sprintf(`
+resource "backupdr_application_compute_vm" "test" {
+  cloudcredential     = "4501"
+  appliance_clusterid = "144292692833"
+  projectid           = "my-project"
+  region              = "us-central1"
+  vmids               = ["%s"]
+}
+`, strings.Join(vmIDs, `", "`))
+}
+
+func TestAccApplicationComputeVMResource(t *testing.T) {
+	console := newFakeConsole(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             console.CheckDestroyed("application"),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccApplicationComputeVMResourceConfig(console, "4711001", "4711002"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("backupdr_application_compute_vm.test", "id", "4501/144292692833/my-project/us-central1"),
+					resource.TestCheckResourceAttr("backupdr_application_compute_vm.test", "applications.%", "2"),
+					resource.TestCheckResourceAttrSet("backupdr_application_compute_vm.test", "applications.4711001"),
+					resource.TestCheckResourceAttrSet("backupdr_application_compute_vm.test", "applications.4711002"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "backupdr_application_compute_vm.test",
+				ImportState:             true,
+				ImportStateId:           "4501/144292692833/my-project/us-central1/4711001,4711002",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"status"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccApplicationComputeVMResourceConfig(console, "4711002", "4711003"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("backupdr_application_compute_vm.test", "applications.%", "2"),
+					resource.TestCheckNoResourceAttr("backupdr_application_compute_vm.test", "applications.4711001"),
+					resource.TestCheckResourceAttrSet("backupdr_application_compute_vm.test", "applications.4711003"),
+					func(*terraform.State) error {
+						if id := console.Find("application", "uniquename", "4711001"); id != "" {
+							return fmt.Errorf("application %s of removed VM 4711001 is still registered", id)
+						}
+						return nil
+					},
+				),
+			},
+			// Drift testing
+			{
+				PreConfig: func() {
+					console.Delete("application", console.Find("application", "uniquename", "4711003"))
+				},
+				Config:             testAccApplicationComputeVMResourceConfig(console, "4711002", "4711003"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// The VM is registered again
+			{
+				Config: testAccApplicationComputeVMResourceConfig(console, "4711002", "4711003"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("backupdr_application_compute_vm.test", "applications.4711003"),
+				),
+			},
+		},
+	})
+}
+
+func TestApplicationComputeVMResource_deleteApplication(t *testing.T) {
+	console := newFakeConsole(t)
+	applicationID := console.Seed("application", map[string]any{"uniquename": "4711001"})
+	r := &applicationComputeVMsResource{client: console.Client()}
+
+	var diags diag.Diagnostics
+	if !r.deleteApplication(context.Background(), "4711001", applicationID, &diags) {
+		t.Fatalf("got %v", diags)
+	}
+	if console.Get("application", applicationID) != nil {
+		t.Error("the application is still registered")
+	}
+
+	// The application is already gone.
+	if !r.deleteApplication(context.Background(), "4711001", applicationID, &diags) || diags.HasError() {
+		t.Errorf("got %v for an application already removed in the console", diags)
+	}
+}
@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	backupdr "github.com/umeshkumhar/backupdr-client"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &applicationDataSource{}
+	_ datasource.DataSourceWithConfigure = &applicationDataSource{}
+)
+
+// applicationDataSource is the data source implementation.
+type applicationDataSource struct {
+	client  *backupdr.APIClient
+	authCtx context.Context
+}
+
+// tf go model
+type applicationDataSourceModel struct {
+	Host               types.String `tfsdk:"host"`
+	Appliance          types.String `tfsdk:"appliance"`
+	ID                 types.String `tfsdk:"id"`
+	Href               types.String `tfsdk:"href"`
+	Stale              types.Bool   `tfsdk:"stale"`
+	Appname            types.String `tfsdk:"appname"`
+	Apptype            types.String `tfsdk:"apptype"`
+	Friendlytype       types.String `tfsdk:"friendlytype"`
+	Uniquename         types.String `tfsdk:"uniquename"`
+	Managed            types.Bool   `tfsdk:"managed"`
+	Isvm               types.Bool   `tfsdk:"isvm"`
+	HostID             types.String `tfsdk:"host_id"`
+	Hostname           types.String `tfsdk:"hostname"`
+	ApplianceID        types.String `tfsdk:"appliance_id"`
+	ApplianceClusterID types.String `tfsdk:"appliance_clusterid"`
+	SlaID              types.String `tfsdk:"sla_id"`
+}
+
+// NewApplicationDataSource - Datasource for Application
+func NewApplicationDataSource() datasource.DataSource {
+	return &applicationDataSource{}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *applicationDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*backupdrProvider).client
+	d.authCtx = req.ProviderData.(*backupdrProvider).authCtx
+}
+
+func (d *applicationDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_application"
+}
+
+func (d *applicationDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := applicationItemAttributes()
+	attributes["apptype"] = schema.StringAttribute{
+		Optional:            true,
+		Computed:            true,
+		MarkdownDescription: "Provide the application type to filter by, for example `GCPInstance` or `VMBackup`.",
+	}
+	attributes["appname"] = schema.StringAttribute{
+		Optional:            true,
+		Computed:            true,
+		MarkdownDescription: "Provide the application name to filter by.",
+	}
+	attributes["uniquename"] = schema.StringAttribute{
+		Optional:            true,
+		Computed:            true,
+		MarkdownDescription: "Provide the unique name to filter by. For VMs this is the GCP instance ID or VMware VM UUID.",
+	}
+	attributes["managed"] = schema.BoolAttribute{
+		Optional:            true,
+		Computed:            true,
+		MarkdownDescription: "Set to true to only match an application protected by a backup plan, or false to only match an unprotected one.",
+	}
+	attributes["host"] = schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "Provide the ID, name or hostname of the host the application belongs to.",
+	}
+	attributes["appliance"] = schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "Provide the ID, cluster ID or name of the backup/recovery appliance that discovered the application.",
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to read information about a single application discovered by the backup/recovery appliances. The filters must match exactly one application.",
+		Attributes:          attributes,
+	}
+}
+
+func (d *applicationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !checkProviderConfigured(d.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state applicationDataSourceModel
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := newApplicationFilter(state.Apptype, state.Appname, state.Host, state.Appliance, state.Uniquename, state.Managed)
+	if filter == (applicationFilter{}) {
+		resp.Diagnostics.AddError(
+			"Missing Application Filter",
+			"Set at least one of apptype, appname, host, appliance, uniquename or managed to select the application.",
+		)
+		return
+	}
+
+	applications, err := listApplications(d.client, d.authCtx, filter, listOptions{})
+	if err != nil {
+		addConsoleError(&resp.Diagnostics,
+			"Unable to Read BackupDR Application",
+			"Could not list applications matching "+filter.String()+".",
+			nil, err, nil,
+		)
+		return
+	}
+
+	switch len(applications) {
+	case 0:
+		resp.Diagnostics.AddError(
+			"No BackupDR Application Found",
+			"No application matches "+filter.String()+".",
+		)
+		return
+	case 1:
+	default:
+		matches := make([]string, 0, len(applications))
+		for _, application := range applications {
+			matches = append(matches, fmt.Sprintf("%s (%s)", application.Id, application.Appname))
+		}
+		resp.Diagnostics.AddError(
+			"Multiple BackupDR Applications Found",
+			fmt.Sprintf("%d applications match %s: %s. Add filters, such as uniquename, to select a single application.", len(applications), filter, strings.Join(matches, ", ")),
+		)
+		return
+	}
+
+	// Map response body to model
+	item := newApplicationItemRestModel(applications[0])
+	state.ID = item.ID
+	state.Href = item.Href
+	state.Stale = item.Stale
+	state.Appname = item.Appname
+	state.Apptype = item.Apptype
+	state.Friendlytype = item.Friendlytype
+	state.Uniquename = item.Uniquename
+	state.Managed = item.Managed
+	state.Isvm = item.Isvm
+	state.HostID = item.HostID
+	state.Hostname = item.Hostname
+	state.ApplianceID = item.ApplianceID
+	state.ApplianceClusterID = item.ApplianceClusterID
+	state.SlaID = item.SlaID
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
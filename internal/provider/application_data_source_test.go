@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccApplicationDataSource(t *testing.T) {
+	console := newFakeConsole(t)
+	applicationID := console.Seed("application", map[string]any{
+		"appname":    "web-1",
+		"apptype":    "GCPInstance",
+		"uniquename": "6279367390186066186",
+		"cluster":    map[string]any{"id": "1415", "clusterid": "144292692833"},
+	})
+	console.Seed("application", map[string]any{
+		"appname":    "web-2",
+		"apptype":    "GCPInstance",
+		"uniquename": "6279367390186066187",
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(console) + `
+data "backupdr_application" "test" {
+  uniquename = "6279367390186066186"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backupdr_application.test", "id", applicationID),
+					resource.TestCheckResourceAttr("data.backupdr_application.test", "appname", "web-1"),
+					resource.TestCheckResourceAttr("data.backupdr_application.test", "appliance_clusterid", "144292692833"),
+				),
+			},
+			{
+				Config: testAccProviderConfig(console) + `
+data "backupdr_application" "test" {
+  apptype = "GCPInstance"
+}
+`,
+				ExpectError: regexp.MustCompile("Multiple BackupDR Applications Found"),
+			},
+		},
+	})
+}
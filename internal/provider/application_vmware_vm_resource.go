@@ -3,24 +3,41 @@ package provider
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/antihax/optional"
 	backupdr "github.com/umeshkumhar/backupdr-client"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &applicationVmwareVMsResource{}
-	_ resource.ResourceWithConfigure   = &applicationVmwareVMsResource{}
-	_ resource.ResourceWithImportState = &applicationVmwareVMsResource{}
+	_ resource.Resource                 = &applicationVmwareVMsResource{}
+	_ resource.ResourceWithConfigure    = &applicationVmwareVMsResource{}
+	_ resource.ResourceWithImportState  = &applicationVmwareVMsResource{}
+	_ resource.ResourceWithUpgradeState = &applicationVmwareVMsResource{}
 )
 
+// applicationVmwareVMConsoleFields maps the fields of vCenter VM discovery requests to the attributes
+// they are set from, so console errors are reported against them.
+var applicationVmwareVMConsoleFields = map[string]path.Path{
+	"cluster": path.Root("appliance_id"),
+	"vms":     path.Root("vms"),
+}
+
 // NewApplicationVmwareVMsResource to create vCenter Host
 func NewApplicationVmwareVMsResource() resource.Resource {
 	return &applicationVmwareVMsResource{}
@@ -30,16 +47,23 @@ func NewApplicationVmwareVMsResource() resource.Resource {
 type applicationVmwareVMsResource struct {
 	client  *backupdr.APIClient
 	authCtx context.Context
+	// applications is the application index shared by the resources of
+	// the provider instance.
+	applications *applicationIndex
 }
 
 // tf go model
 type applicationVmwareVMsResourceModel struct {
-	ApplianceID  types.String   `tfsdk:"appliance_id"`
-	ClusterName  types.String   `tfsdk:"cluster_name"`
-	VMs          []types.String `tfsdk:"vms"`
-	VcenterID    types.String   `tfsdk:"vcenter_id"`
-	Status       types.String   `tfsdk:"status"`
-	Applications types.List     `tfsdk:"applications"`
+	ID               types.String   `tfsdk:"id"`
+	ApplianceID      types.String   `tfsdk:"appliance_id"`
+	ClusterName      types.String   `tfsdk:"cluster_name"`
+	VMs              []types.String `tfsdk:"vms"`
+	VcenterID        types.String   `tfsdk:"vcenter_id"`
+	Status           types.String   `tfsdk:"status"`
+	DiscoveryTimeout types.String   `tfsdk:"discovery_timeout"`
+	Applications     types.Map      `tfsdk:"applications"`
+	ForceDelete      types.Bool     `tfsdk:"force_delete"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
 }
 
 // Metadata returns the resource type name.
@@ -48,38 +72,68 @@ func (r *applicationVmwareVMsResource) Metadata(_ context.Context, req resource.
 }
 
 // Schema defines the schema for the resource.
-func (r *applicationVmwareVMsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *applicationVmwareVMsResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:             1,
 		Description:         "Manages an vCenter Host to add Virtual Machines.",
 		MarkdownDescription: "You can use this resource to onboard VMware VMs as an application into the Backup and DR Service. After you onboard the application, you can perform backup or restore operations.",
 		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "It displays the resource ID in the format `appliance_id/vcenter_id/cluster_name`.",
+			},
 			"appliance_id": schema.StringAttribute{
-				Required:            true,
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 				MarkdownDescription: "Provide the backup/recovery appliance ID.",
 			},
 			"vcenter_id": schema.StringAttribute{
-				Required:            true,
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 				MarkdownDescription: "Provide the ID for the vCenter host.",
 			},
 			"cluster_name": schema.StringAttribute{
-				Required:            true,
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 				MarkdownDescription: "Provide a cluster name of the vCenter.",
 			},
-			"vms": schema.ListAttribute{
+			"vms": schema.SetAttribute{
 				Required:            true,
 				ElementType:         types.StringType,
-				MarkdownDescription: "Provide the list of VMs UUID.",
+				MarkdownDescription: "Provide the set of VMs UUID. Adding or removing a UUID only registers or unregisters that VM.",
 			},
 			"status": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "It displays the status of the request.",
 			},
-			"applications": schema.ListAttribute{
+			"discovery_timeout": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultDiscoveryTimeout),
+				MarkdownDescription: "How long to wait for the appliance to register newly added VMs as applications, such as `30s` or `10m`. Defaults to `5m`.",
+			},
+			"applications": schema.MapAttribute{
 				Computed:            true,
 				ElementType:         types.StringType,
-				MarkdownDescription: "It displays the list of application IDs.",
+				MarkdownDescription: "It displays the application IDs keyed by VM UUID.",
+			},
+			"force_delete": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Set to true to remove the backup plans protecting the VMs when they are removed from `vms` or the resource is destroyed. By default removal fails while a VM is still protected by a backup plan.",
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": resourceTimeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -91,10 +145,15 @@ func (r *applicationVmwareVMsResource) Configure(_ context.Context, req resource
 
 	r.client = req.ProviderData.(*backupdrProvider).client
 	r.authCtx = req.ProviderData.(*backupdrProvider).authCtx
+	r.applications = req.ProviderData.(*backupdrProvider).applications
 }
 
 // Create a new resource.
 func (r *applicationVmwareVMsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !checkProviderConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	// Retrieve values from plan
 	var plan applicationVmwareVMsResourceModel
 	var listVMs []string
@@ -104,165 +163,202 @@ func (r *applicationVmwareVMsResource) Create(ctx context.Context, req resource.
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, authCtx, cancel := withTimeout(ctx, r.authCtx, createTimeout)
+	defer cancel()
+
 	for _, vm := range plan.VMs {
 		listVMs = append(listVMs, vm.ValueString())
 	}
 
-	reqVcenterHostAddVMs := backupdr.VmDiscoveryRest{
-		Cluster: plan.ApplianceID.ValueString(),
-		Addvms:  true,
-		Vms:     listVMs,
+	discoveryTimeout := parseDurationAttribute(path.Root("discovery_timeout"), plan.DiscoveryTimeout.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	// Generate API request body from plan
-	reqBody := backupdr.HostApiVmAddNewOpts{
-		Body: optional.NewInterface(reqVcenterHostAddVMs),
+	status, ok := r.addVMs(authCtx, plan, listVMs, &resp.Diagnostics)
+	if !ok {
+		return
 	}
+	plan.Status = types.StringValue(status)
 
-	// Add new VMs of vCenter Host
-	respObject, err := r.client.HostApi.VmAddNew(r.authCtx, plan.VcenterID.ValueString(), plan.ClusterName.ValueString(), &reqBody)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error adding VMs of the vCenter Host",
-			"Could not add VMs of vCenter Host, unexpected error: "+err.Error(),
-		)
+	// Unresolved VMs fail the apply. The VMs that were resolved are still
+	// saved, which leaves the resource tainted so it is replaced next time.
+	applicationIDs := make(map[string]attr.Value, len(listVMs))
+	resolveApplications(ctx, r.applications, r.client, authCtx, listVMs, discoveryTimeout, applicationIDs, &resp.Diagnostics)
+
+	plan.Applications, diags = types.MapValue(types.StringType, applicationIDs)
+	resp.Diagnostics.Append(diags...)
+
+	plan.ID = types.StringValue(strings.Join([]string{
+		plan.ApplianceID.ValueString(),
+		plan.VcenterID.ValueString(),
+		plan.ClusterName.ValueString(),
+	}, "/"))
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+}
 
-	if respObject.StatusCode == 200 || respObject.StatusCode == 204 {
-		// Map response body to schema and populate Computed attribute values
-		plan.Status = types.StringValue(respObject.Status)
-		applicationIDs := make([]attr.Value, 0)
+// Read resource information.
+func (r *applicationVmwareVMsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if unknownProviderConfig(ctx, r.client) {
+		return
+	}
 
-		for _, application := range listVMs {
+	// Get current state
+	var state applicationVmwareVMsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-			filter := backupdr.ApplicationApiListApplicationsOpts{
-				Filter: optional.NewString(fmt.Sprintf("uniquename:==%s", application)),
-			}
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, authCtx, cancel := withTimeout(ctx, r.authCtx, readTimeout)
+	defer cancel()
+
+	// Refresh the application IDs by VM uniquename and drop the VMs that are
+	// no longer registered, so they are planned to be added again.
+	names := make([]string, 0, len(state.VMs))
+	for _, vm := range state.VMs {
+		names = append(names, vm.ValueString())
+	}
+	applications, err := r.applications.Resolve(r.client, authCtx, names, time.Time{})
+	if err != nil {
+		addConsoleError(&resp.Diagnostics,
+			"Error Reading vCenter VM Applications",
+			"Could not read the applications of the vCenter VMs.",
+			nil, err, nil,
+		)
+		return
+	}
 
-			// fetch application ID with filter
-			lsApps, res, err := r.client.ApplicationApi.ListApplications(r.authCtx, &filter)
-			if err != nil {
-				resp.Diagnostics.AddError(
-					"Error listing applications",
-					"Could not list applications, unexpected error: "+res.Status+" : "+err.Error(),
-				)
-				return
-			}
-			// capture the id of filtered application
-			if lsApps.Items != nil && len(lsApps.Items) > 0 {
-				applicationIDs = append(applicationIDs, types.StringValue(lsApps.Items[0].Id))
-			} else {
-				applicationIDs = append(applicationIDs, types.StringValue("unknown-error"))
-			}
+	vms := make([]types.String, 0, len(state.VMs))
+	applicationIDs := make(map[string]attr.Value, len(state.VMs))
+	for _, vm := range state.VMs {
+		application, ok := applications[vm.ValueString()]
+		if !ok {
+			tflog.Warn(ctx, "vCenter VM is no longer registered as an application, removing it from state", map[string]any{"vm": vm.ValueString()})
+			continue
 		}
-		if len(applicationIDs) > 0 {
-			plan.Applications, diags = types.ListValue(types.StringType, applicationIDs)
-			resp.Diagnostics.Append(diags...)
-			if resp.Diagnostics.HasError() {
-				return
-			}
-		}
-	} else {
-		plan.Status = types.StringValue(respObject.Status)
-		resp.Diagnostics.AddError(
-			"Error adding VMs of the vCenter Host",
-			"Could not add VMs of vCenter Host, unexpected error: "+err.Error(),
-		)
+		vms = append(vms, vm)
+		applicationIDs[vm.ValueString()] = types.StringValue(application.Id)
+	}
+
+	if len(vms) == 0 {
+		resp.State.RemoveResource(ctx)
 		return
 	}
 
-	// Set state to fully populated data
-	diags = resp.State.Set(ctx, plan)
+	state.VMs = vms
+	state.Applications, diags = types.MapValue(types.StringType, applicationIDs)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-}
 
-// Read resource information.
-func (r *applicationVmwareVMsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	// Not implemented: no method exists to read vCenter VMs
-	return
+	// State upgraded from version 0 predates discovery_timeout
+	if state.DiscoveryTimeout.IsNull() {
+		state.DiscoveryTimeout = types.StringValue(defaultDiscoveryTimeout)
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 }
 
 func (r *applicationVmwareVMsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !checkProviderConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	// Retrieve values from plan
 	var plan applicationVmwareVMsResourceModel
-	var listVMs []string
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	for _, vm := range plan.VMs {
-		listVMs = append(listVMs, vm.ValueString())
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	ctx, authCtx, cancel := withTimeout(ctx, r.authCtx, updateTimeout)
+	defer cancel()
 
-	reqVcenterHostAddVMs := backupdr.VmDiscoveryRest{
-		Cluster: plan.ApplianceID.ValueString(),
-		Addvms:  true,
-		Vms:     listVMs,
+	// Get current state
+	var state applicationVmwareVMsResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	// Generate API request body from plan
-	reqBody := backupdr.HostApiVmAddNewOpts{
-		Body: optional.NewInterface(reqVcenterHostAddVMs),
+	discoveryTimeout := parseDurationAttribute(path.Root("discovery_timeout"), plan.DiscoveryTimeout.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	// Add new VMs of vCenter Host
-	respObject, err := r.client.HostApi.VmAddNew(r.authCtx, plan.VcenterID.ValueString(), plan.ClusterName.ValueString(), &reqBody)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error updating VMs of the vCenter Host",
-			"Could not update VMs of vCenter Host, unexpected error: "+err.Error(),
-		)
+	addedVMs, removedVMs := diffVMs(state.VMs, plan.VMs)
+	applicationIDs := state.Applications.Elements()
+
+	// Unregister the VMs removed from the set
+	removed := make(map[string]string, len(removedVMs))
+	for _, vm := range removedVMs {
+		if application, ok := applicationIDs[vm]; ok {
+			removed[vm] = application.(types.String).ValueString()
+		}
+	}
+	if !r.removeApplications(authCtx, removed, plan.ForceDelete.ValueBool(), &resp.Diagnostics) {
 		return
 	}
+	for vm := range removed {
+		delete(applicationIDs, vm)
+	}
 
-	if respObject.StatusCode == 200 || respObject.StatusCode == 204 {
-		// Map response body to schema and populate Computed attribute values
-		plan.Status = types.StringValue(respObject.Status)
-		applicationIDs := make([]attr.Value, 0)
-		applicationIDs = append(applicationIDs, plan.Applications.Elements()...)
-
-		for _, application := range listVMs {
-			filter := backupdr.ApplicationApiListApplicationsOpts{
-				Filter: optional.NewString(fmt.Sprintf("uniquename:==%s", application)),
-			}
-			// fetch application ID with filter
-			lsApps, res, err := r.client.ApplicationApi.ListApplications(r.authCtx, &filter)
-			if err != nil {
-				resp.Diagnostics.AddError(
-					"Error listing applications",
-					"Could not list applications, unexpected error: "+res.Status+" : "+err.Error(),
-				)
-				return
-			}
-			// capture the id of filtered application
-			if lsApps.Items != nil && len(lsApps.Items) > 0 {
-				applicationIDs = append(applicationIDs, types.StringValue(lsApps.Items[0].Id))
-			} else {
-				applicationIDs = append(applicationIDs, types.StringValue("unknown-error"))
-			}
+	// Discover only the VMs added to the set
+	plan.Status = state.Status
+	if len(addedVMs) > 0 {
+		status, ok := r.addVMs(authCtx, plan, addedVMs, &resp.Diagnostics)
+		if !ok {
+			return
 		}
-		if len(applicationIDs) > 0 {
-			plan.Applications, diags = types.ListValue(types.StringType, applicationIDs)
-			resp.Diagnostics.Append(diags...)
-			if resp.Diagnostics.HasError() {
-				return
+		plan.Status = types.StringValue(status)
+
+		// Keep the unresolved VMs out of state so they are added again on
+		// the next apply.
+		if !resolveApplications(ctx, r.applications, r.client, authCtx, addedVMs, discoveryTimeout, applicationIDs, &resp.Diagnostics) {
+			resolvedVMs := make([]types.String, 0, len(plan.VMs))
+			for _, vm := range plan.VMs {
+				if _, ok := applicationIDs[vm.ValueString()]; ok {
+					resolvedVMs = append(resolvedVMs, vm)
+				}
 			}
+			plan.VMs = resolvedVMs
 		}
-	} else {
-		plan.Status = types.StringValue(respObject.Status)
-		resp.Diagnostics.AddError(
-			"Error updating VMs of the vCenter Host",
-			"Could not update VMs of vCenter Host, unexpected error: "+err.Error(),
-		)
-		return
 	}
 
+	plan.Applications, diags = types.MapValue(types.StringType, applicationIDs)
+	resp.Diagnostics.Append(diags...)
+
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -271,11 +367,174 @@ func (r *applicationVmwareVMsResource) Update(ctx context.Context, req resource.
 }
 
 func (r *applicationVmwareVMsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	// Not implemented: no method exists to read vCenter VMs
-	return
+	if !checkProviderConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	// Retrieve values from state
+	var state applicationVmwareVMsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, authCtx, cancel := withTimeout(ctx, r.authCtx, deleteTimeout)
+	defer cancel()
+
+	applications := make(map[string]string, len(state.Applications.Elements()))
+	for vm, application := range state.Applications.Elements() {
+		applications[vm] = application.(types.String).ValueString()
+	}
+	r.removeApplications(authCtx, applications, state.ForceDelete.ValueBool(), &resp.Diagnostics)
 }
 
+// ImportState imports vCenter VMs with an ID in the format
+// appliance_id/vcenter_id/cluster_name/vm_uuid1,vm_uuid2.
 func (r *applicationVmwareVMsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	if !checkProviderConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 4 || parts[0] == "" || parts[1] == "" || parts[2] == "" || parts[3] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: appliance_id/vcenter_id/cluster_name/vm_uuid1,vm_uuid2. Got: %q", req.ID),
+		)
+		return
+	}
+
+	vms := make([]types.String, 0)
+	for _, vm := range strings.Split(parts[3], ",") {
+		if vm = strings.TrimSpace(vm); vm != "" {
+			vms = append(vms, types.StringValue(vm))
+		}
+	}
+
+	state := applicationVmwareVMsResourceModel{
+		ID:               types.StringValue(strings.Join(parts[:3], "/")),
+		ApplianceID:      types.StringValue(parts[0]),
+		VcenterID:        types.StringValue(parts[1]),
+		ClusterName:      types.StringValue(parts[2]),
+		VMs:              vms,
+		Status:           types.StringNull(),
+		DiscoveryTimeout: types.StringValue(defaultDiscoveryTimeout),
+		Applications:     types.MapNull(types.StringType),
+		ForceDelete:      types.BoolNull(),
+		Timeouts:         nullTimeouts(),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// UpgradeState converts the positional applications list of version 0 into
+// a map keyed by VM UUID.
+func (r *applicationVmwareVMsResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: upgradeApplicationsToMap("vms"),
+	}
+}
+
+// addVMs asks the appliance to discover and register the given vCenter VMs
+// and returns the status of the request.
+func (r *applicationVmwareVMsResource) addVMs(authCtx context.Context, plan applicationVmwareVMsResourceModel, vms []string, diags *diag.Diagnostics) (string, bool) {
+	reqVcenterHostAddVMs := backupdr.VmDiscoveryRest{
+		Cluster: plan.ApplianceID.ValueString(),
+		Addvms:  true,
+		Vms:     vms,
+	}
+
+	// Generate API request body from plan
+	reqBody := backupdr.HostApiVmAddNewOpts{
+		Body: optional.NewInterface(reqVcenterHostAddVMs),
+	}
+
+	// Add new VMs of vCenter Host
+	respObject, err := r.client.HostApi.VmAddNew(authCtx, plan.VcenterID.ValueString(), plan.ClusterName.ValueString(), &reqBody)
+	if err != nil {
+		addConsoleError(diags,
+			"Error adding VMs of the vCenter Host",
+			"Could not add VMs of vCenter Host.",
+			respObject, err, applicationVmwareVMConsoleFields,
+		)
+		return "", false
+	}
+
+	if respObject.StatusCode != 200 && respObject.StatusCode != 204 {
+		diags.AddError(
+			"Error adding VMs of the vCenter Host",
+			"Could not add VMs of vCenter Host, unexpected status: "+respObject.Status,
+		)
+		return "", false
+	}
+
+	return respObject.Status, true
+}
+
+// removeApplications unregisters the given applications, keyed by VM UUID.
+// Every application is checked before any is removed, so a protected VM does
+// not leave the set half deleted; backup plans are only removed with force.
+// Applications and backup plans already removed in the console are skipped.
+func (r *applicationVmwareVMsResource) removeApplications(authCtx context.Context, applicationIDs map[string]string, force bool, diags *diag.Diagnostics) bool {
+	applications := make([]backupdr.ApplicationRest, 0, len(applicationIDs))
+	var protected []string
+	for vm, applicationID := range applicationIDs {
+		respObject, res, err := r.client.ApplicationApi.GetApplication(authCtx, applicationID)
+		if isNotFound(res, err) {
+			continue
+		}
+		if err != nil {
+			addConsoleError(diags,
+				"Error Reading vCenter VM Application",
+				"Could not read application "+applicationID+" of VM "+vm+".",
+				res, err, nil,
+			)
+			return false
+		}
+		if respObject.Sla != nil && respObject.Sla.Id != "" {
+			protected = append(protected, fmt.Sprintf("%s (backup plan %s)", applicationID, respObject.Sla.Id))
+		}
+		applications = append(applications, respObject)
+	}
+
+	if len(protected) > 0 && !force {
+		sort.Strings(protected)
+		diags.AddError(
+			"vCenter VM Applications Are Still Protected",
+			"The following applications still have a backup plan: "+strings.Join(protected, ", ")+". "+
+				"Remove the backup plans first or set force_delete = true to remove them together with the applications.",
+		)
+		return false
+	}
+
+	for _, application := range applications {
+		if application.Sla != nil && application.Sla.Id != "" {
+			res, err := r.client.SLAApi.DeleteSla(authCtx, application.Sla.Id)
+			if err != nil && !isNotFound(res, err) {
+				addConsoleError(diags,
+					"Error Deleting Backup Plan",
+					"Could not delete backup plan "+application.Sla.Id+" of application "+application.Id+".",
+					res, err, nil,
+				)
+				return false
+			}
+		}
+
+		res, err := r.client.ApplicationApi.DeleteApplication(authCtx, application.Id)
+		if err != nil && !isNotFound(res, err) {
+			addConsoleError(diags,
+				"Error Deleting vCenter VM Application",
+				"Could not delete application "+application.Id+".",
+				res, err, nil,
+			)
+			return false
+		}
+	}
+	return true
 }
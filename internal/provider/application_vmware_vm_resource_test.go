@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func testAccApplicationVmwareVMResourceConfig(console *fakeConsole, forceDelete bool, vms ...string) string {
+	return testAccProviderConfig(console) + fmt.Sprintf(`
+resource "backupdr_application_vmware_vm" "test" {
+  appliance_id = "1415"
+  vcenter_id   = "4501"
+  cluster_name = "cluster-1"
+  vms          = ["%s"]
+  force_delete = %t
+}
+`, strings.Join(vms, `", "`), forceDelete)
+}
+
+func TestAccApplicationVmwareVMResource(t *testing.T) {
+	console := newFakeConsole(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			console.CheckDestroyed("application"),
+			console.CheckDestroyed("sla"),
+		),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccApplicationVmwareVMResourceConfig(console, false, "vm-uuid-1", "vm-uuid-2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("backupdr_application_vmware_vm.test", "id", "1415/4501/cluster-1"),
+					resource.TestCheckResourceAttr("backupdr_application_vmware_vm.test", "applications.%", "2"),
+					resource.TestCheckResourceAttrSet("backupdr_application_vmware_vm.test", "applications.vm-uuid-1"),
+					resource.TestCheckResourceAttrSet("backupdr_application_vmware_vm.test", "applications.vm-uuid-2"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "backupdr_application_vmware_vm.test",
+				ImportState:             true,
+				ImportStateId:           "1415/4501/cluster-1/vm-uuid-1,vm-uuid-2",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"status", "force_delete"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccApplicationVmwareVMResourceConfig(console, false, "vm-uuid-2", "vm-uuid-3"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("backupdr_application_vmware_vm.test", "applications.%", "2"),
+					resource.TestCheckNoResourceAttr("backupdr_application_vmware_vm.test", "applications.vm-uuid-1"),
+					resource.TestCheckResourceAttrSet("backupdr_application_vmware_vm.test", "applications.vm-uuid-3"),
+					func(*terraform.State) error {
+						if id := console.Find("application", "uniquename", "vm-uuid-1"); id != "" {
+							return fmt.Errorf("application %s of removed VM vm-uuid-1 is still registered", id)
+						}
+						return nil
+					},
+				),
+			},
+			// Drift testing
+			{
+				PreConfig: func() {
+					console.Delete("application", console.Find("application", "uniquename", "vm-uuid-3"))
+				},
+				Config:             testAccApplicationVmwareVMResourceConfig(console, false, "vm-uuid-2", "vm-uuid-3"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// The VM is registered again
+			{
+				Config: testAccApplicationVmwareVMResourceConfig(console, false, "vm-uuid-2", "vm-uuid-3"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("backupdr_application_vmware_vm.test", "applications.vm-uuid-3"),
+				),
+			},
+			// A VM protected outside Terraform is not removed without force_delete
+			{
+				PreConfig: func() {
+					applicationID := console.Find("application", "uniquename", "vm-uuid-3")
+					slaID := console.Seed("sla", map[string]any{"application": map[string]any{"id": applicationID}})
+					console.Update("application", applicationID, map[string]any{"sla": map[string]any{"id": slaID}})
+				},
+				Config:      testAccApplicationVmwareVMResourceConfig(console, false, "vm-uuid-2", "vm-uuid-3"),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("vCenter VM Applications Are Still Protected"),
+			},
+			// With force_delete the backup plan is removed on destroy
+			{
+				Config: testAccApplicationVmwareVMResourceConfig(console, true, "vm-uuid-2", "vm-uuid-3"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("backupdr_application_vmware_vm.test", "force_delete", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestApplicationVmwareVMResource_removeApplications(t *testing.T) {
+	console := newFakeConsole(t)
+	kept := console.Seed("application", map[string]any{"uniquename": "vm-uuid-1"})
+	slaID := console.Seed("sla", map[string]any{"application": map[string]any{"id": kept}})
+	console.Update("application", kept, map[string]any{"sla": map[string]any{"id": slaID}})
+	gone := console.Seed("application", map[string]any{"uniquename": "vm-uuid-2"})
+	console.Delete("application", gone)
+	r := &applicationVmwareVMsResource{client: console.Client()}
+
+	// The application still refers to a backup plan removed in the console.
+	console.Delete("sla", slaID)
+	console.Update("application", kept, map[string]any{"sla": map[string]any{"id": slaID}})
+
+	var diags diag.Diagnostics
+	if !r.removeApplications(context.Background(), map[string]string{"vm-uuid-1": kept, "vm-uuid-2": gone}, true, &diags) || diags.HasError() {
+		t.Fatalf("got %v for applications and backup plans already removed in the console", diags)
+	}
+	if console.Get("application", kept) != nil {
+		t.Error("the application is still registered")
+	}
+}
@@ -0,0 +1,361 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/antihax/optional"
+	backupdr "github.com/umeshkumhar/backupdr-client"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// applicationIndex holds the applications registered on the console, keyed by
+// unique name, so VMs are resolved from a single paged listing instead of a
+// request per VM. A provider instance shares one index between its
+// resources, which in a Terraform run lets every application resource
+// refreshed in the same walk reuse the same listing.
+type applicationIndex struct {
+	mu           sync.Mutex
+	byUniquename map[string]backupdr.ApplicationRest
+	// byHost holds the applications by the unique name of their host, for
+	// applications registered without one of their own.
+	byHost map[string]backupdr.ApplicationRest
+	// loadedAt is when the current listing was requested. It is zero
+	// until the index is first loaded.
+	loadedAt time.Time
+}
+
+// newApplicationIndex returns an index that is loaded on first use.
+func newApplicationIndex() *applicationIndex {
+	return &applicationIndex{}
+}
+
+// Resolve returns the applications of the given VMs, keyed by VM, leaving out
+// the VMs that are not registered. The applications are listed again unless
+// the index was loaded at or after fresh; a zero fresh accepts any listing.
+func (x *applicationIndex) Resolve(client *backupdr.APIClient, authCtx context.Context, vms []string, fresh time.Time) (map[string]backupdr.ApplicationRest, error) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	if x.loadedAt.IsZero() || x.loadedAt.Before(fresh) {
+		if err := x.load(client, authCtx); err != nil {
+			return nil, err
+		}
+	}
+
+	applications := make(map[string]backupdr.ApplicationRest, len(vms))
+	for _, vm := range vms {
+		if application, ok := x.byUniquename[vm]; ok {
+			applications[vm] = application
+		} else if application, ok := x.byHost[vm]; ok {
+			applications[vm] = application
+		}
+	}
+	return applications, nil
+}
+
+// load lists every application and rebuilds the index. Callers
+// must hold x.mu.
+func (x *applicationIndex) load(client *backupdr.APIClient, authCtx context.Context) error {
+	loadedAt := time.Now()
+	applications, err := listApplications(client, authCtx, applicationFilter{}, listOptions{})
+	if err != nil {
+		return err
+	}
+
+	byUniquename := map[string]backupdr.ApplicationRest{}
+	byHost := map[string]backupdr.ApplicationRest{}
+	for _, application := range applications {
+		if application.Uniquename != "" {
+			byUniquename[application.Uniquename] = application
+		}
+		if application.Host != nil && application.Host.Uniquename != "" {
+			byHost[application.Host.Uniquename] = application
+		}
+	}
+
+	x.byUniquename = byUniquename
+	x.byHost = byHost
+	x.loadedAt = loadedAt
+	return nil
+}
+
+// applicationFilter holds the structured filters accepted by the application
+// data sources. Empty fields and a nil Managed match every application.
+type applicationFilter struct {
+	Apptype    string
+	Appname    string
+	Host       string
+	Appliance  string
+	Uniquename string
+	Managed    *bool
+}
+
+// expressions compiles the filters the console can evaluate into its filter
+// syntax, most selective first. Host and appliance filters accept several
+// identifiers and are only evaluated by matches.
+func (f applicationFilter) expressions() []string {
+	var exprs []string
+	if f.Uniquename != "" {
+		exprs = append(exprs, "uniquename:=="+f.Uniquename)
+	}
+	if f.Appname != "" {
+		exprs = append(exprs, "appname:=="+f.Appname)
+	}
+	if f.Apptype != "" {
+		exprs = append(exprs, "apptype:=="+f.Apptype)
+	}
+	if f.Managed != nil {
+		exprs = append(exprs, fmt.Sprintf("managed:==%t", *f.Managed))
+	}
+	return exprs
+}
+
+// matches reports whether application satisfies every filter. The host
+// filter matches the host ID, name or hostname, and the appliance filter
+// matches the appliance ID, cluster ID or name.
+func (f applicationFilter) matches(application backupdr.ApplicationRest) bool {
+	if f.Uniquename != "" && application.Uniquename != f.Uniquename {
+		return false
+	}
+	if f.Appname != "" && application.Appname != f.Appname {
+		return false
+	}
+	if f.Apptype != "" && application.Apptype != f.Apptype {
+		return false
+	}
+	if f.Managed != nil && application.Managed != *f.Managed {
+		return false
+	}
+	if f.Host != "" {
+		host := application.Host
+		if host == nil || (host.Id != f.Host && host.Name != f.Host && host.Hostname != f.Host) {
+			return false
+		}
+	}
+	if f.Appliance != "" {
+		cluster := application.Cluster
+		if cluster == nil || (cluster.Id != f.Appliance && cluster.Clusterid != f.Appliance && cluster.Name != f.Appliance) {
+			return false
+		}
+	}
+	return true
+}
+
+// String describes the filter for diagnostics.
+func (f applicationFilter) String() string {
+	var parts []string
+	for _, kv := range [][2]string{
+		{"apptype", f.Apptype},
+		{"appname", f.Appname},
+		{"host", f.Host},
+		{"appliance", f.Appliance},
+		{"uniquename", f.Uniquename},
+	} {
+		if kv[1] != "" {
+			parts = append(parts, fmt.Sprintf("%s = %q", kv[0], kv[1]))
+		}
+	}
+	if f.Managed != nil {
+		parts = append(parts, fmt.Sprintf("managed = %t", *f.Managed))
+	}
+	if len(parts) == 0 {
+		return "no filters"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// listApplications returns the applications matching filter, walking every
+// page of the listing up to opts.Limit matches. opts.Filter and every filter
+// the console can evaluate are sent as separate filter parameters, which the
+// console combines; the host and appliance filters are then checked on the
+// results.
+func listApplications(client *backupdr.APIClient, authCtx context.Context, filter applicationFilter, opts listOptions) ([]backupdr.ApplicationRest, error) {
+	exprs := filter.expressions()
+	if opts.Filter != "" {
+		exprs = append([]string{opts.Filter}, exprs...)
+	}
+	// The client sends a single filter parameter, so the filters are added
+	// to the query by queryTransport.
+	if len(exprs) > 0 {
+		authCtx = withQueryParams(authCtx, url.Values{"filter": exprs})
+	}
+
+	return listAll(opts.Limit, func(limit, offset int64) ([]backupdr.ApplicationRest, int32, error) {
+		page, res, err := client.ApplicationApi.ListApplications(authCtx, &backupdr.ApplicationApiListApplicationsOpts{
+			Sort:   optionalString(opts.Sort),
+			Limit:  optional.NewInt64(limit),
+			Offset: optional.NewInt64(offset),
+		})
+		if err != nil {
+			return nil, 0, newRequestError(res, err)
+		}
+		return page.Items, page.Count, nil
+	}, filter.matches)
+}
+
+// defaultDiscoveryTimeout bounds how long the application VM resources wait
+// for the appliance to register newly added VMs.
+const defaultDiscoveryTimeout = "5m"
+
+// applicationPollInterval is how often the console is asked whether newly
+// added VMs have been registered.
+var applicationPollInterval = 5 * time.Second
+
+// waitForApplications polls the console until every VM is registered as an
+// application or the timeout expires. Every poll lists the applications once
+// for all VMs through index. It returns the application IDs keyed by VM and,
+// sorted as given, the VMs that could not be resolved in time.
+func waitForApplications(ctx context.Context, index *applicationIndex, client *backupdr.APIClient, authCtx context.Context, vms []string, timeout time.Duration) (map[string]string, []string, error) {
+	applicationIDs := make(map[string]string, len(vms))
+	deadline := time.Now().Add(timeout)
+
+	// The VMs were just added, so a listing from before now cannot have
+	// them.
+	fresh := time.Now()
+	pending := vms
+	for {
+		applications, err := index.Resolve(client, authCtx, pending, fresh)
+		if err != nil {
+			return applicationIDs, nil, err
+		}
+
+		var unresolved []string
+		for _, vm := range pending {
+			application, ok := applications[vm]
+			if !ok {
+				unresolved = append(unresolved, vm)
+				continue
+			}
+			applicationIDs[vm] = application.Id
+		}
+
+		if len(unresolved) == 0 || !time.Now().Add(applicationPollInterval).Before(deadline) {
+			return applicationIDs, unresolved, nil
+		}
+		pending = unresolved
+
+		tflog.Debug(ctx, "Waiting for the appliance to register VMs as applications", map[string]any{
+			"unresolved": unresolved,
+		})
+		timer := time.NewTimer(applicationPollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return applicationIDs, pending, nil
+		case <-timer.C:
+		}
+		fresh = time.Now()
+	}
+}
+
+// resolveApplications waits for the appliance to register each VM and records
+// its application ID in applicationIDs. VMs that are still unknown when the
+// timeout expires are reported by name, and left out of applicationIDs.
+func resolveApplications(ctx context.Context, index *applicationIndex, client *backupdr.APIClient, authCtx context.Context, vms []string, timeout time.Duration, applicationIDs map[string]attr.Value, diags *diag.Diagnostics) bool {
+	resolved, unresolved, err := waitForApplications(ctx, index, client, authCtx, vms, timeout)
+	for vm, applicationID := range resolved {
+		applicationIDs[vm] = types.StringValue(applicationID)
+	}
+	if err != nil {
+		addConsoleError(diags,
+			"Error listing applications",
+			"Could not list applications.",
+			nil, err, nil,
+		)
+		return false
+	}
+	if len(unresolved) > 0 {
+		diags.AddError(
+			"Error Resolving VM Applications",
+			fmt.Sprintf("The appliance did not register the following VMs as applications within %s: %s. ", timeout, strings.Join(unresolved, ", "))+
+				"Check that the VMs exist and are reachable from the appliance, or increase discovery_timeout.",
+		)
+		return false
+	}
+	return true
+}
+
+// diffVMs returns the VMs that are planned but not yet in state, and the VMs
+// in state that are no longer planned.
+func diffVMs(prior, planned []types.String) (added, removed []string) {
+	priorSet := make(map[string]bool, len(prior))
+	for _, vm := range prior {
+		priorSet[vm.ValueString()] = true
+	}
+	plannedSet := make(map[string]bool, len(planned))
+	for _, vm := range planned {
+		plannedSet[vm.ValueString()] = true
+		if !priorSet[vm.ValueString()] {
+			added = append(added, vm.ValueString())
+		}
+	}
+	for _, vm := range prior {
+		if !plannedSet[vm.ValueString()] {
+			removed = append(removed, vm.ValueString())
+		}
+	}
+	return added, removed
+}
+
+// upgradeApplicationsToMap upgrades version 0 state of the application VM
+// resources, where applications was a list aligned by position with the VM
+// list held in vmsAttribute, to a map of application IDs keyed by VM.
+func upgradeApplicationsToMap(vmsAttribute string) resource.StateUpgrader {
+	return resource.StateUpgrader{
+		StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+			var rawState map[string]json.RawMessage
+			if err := json.Unmarshal(req.RawState.JSON, &rawState); err != nil {
+				resp.Diagnostics.AddError(
+					"Unable to Upgrade Resource State",
+					"Could not parse prior state: "+err.Error(),
+				)
+				return
+			}
+
+			var vms, applicationIDs []string
+			_ = json.Unmarshal(rawState[vmsAttribute], &vms)
+			_ = json.Unmarshal(rawState["applications"], &applicationIDs)
+
+			applications := make(map[string]string, len(vms))
+			for i, vm := range vms {
+				// Earlier versions stored a placeholder for VMs they could
+				// not resolve; leave those out so Read resolves them again.
+				if i < len(applicationIDs) && applicationIDs[i] != "unknown-error" {
+					applications[vm] = applicationIDs[i]
+				}
+			}
+
+			upgraded, err := json.Marshal(applications)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Unable to Upgrade Resource State",
+					"Could not convert applications: "+err.Error(),
+				)
+				return
+			}
+			rawState["applications"] = upgraded
+
+			contents, err := json.Marshal(rawState)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Unable to Upgrade Resource State",
+					"Could not encode upgraded state: "+err.Error(),
+				)
+				return
+			}
+			resp.DynamicValue = &tfprotov6.DynamicValue{JSON: contents}
+		},
+	}
+}
@@ -0,0 +1,263 @@
+package provider
+
+import (
+	"context"
+
+	backupdr "github.com/umeshkumhar/backupdr-client"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &applicationAllDataSource{}
+	_ datasource.DataSourceWithConfigure = &applicationAllDataSource{}
+)
+
+// applicationAllDataSource is the data source implementation.
+type applicationAllDataSource struct {
+	client  *backupdr.APIClient
+	authCtx context.Context
+}
+
+// tf go model
+type allApplicationsResourceModel struct {
+	Apptype    types.String               `tfsdk:"apptype"`
+	Appname    types.String               `tfsdk:"appname"`
+	Host       types.String               `tfsdk:"host"`
+	Appliance  types.String               `tfsdk:"appliance"`
+	Uniquename types.String               `tfsdk:"uniquename"`
+	Managed    types.Bool                 `tfsdk:"managed"`
+	Limit      types.Int64                `tfsdk:"limit"`
+	Sort       types.String               `tfsdk:"sort"`
+	Filter     types.String               `tfsdk:"filter"`
+	Items      []applicationItemRestModel `tfsdk:"items"`
+}
+
+type applicationItemRestModel struct {
+	ID                 types.String `tfsdk:"id"`
+	Href               types.String `tfsdk:"href"`
+	Stale              types.Bool   `tfsdk:"stale"`
+	Appname            types.String `tfsdk:"appname"`
+	Apptype            types.String `tfsdk:"apptype"`
+	Friendlytype       types.String `tfsdk:"friendlytype"`
+	Uniquename         types.String `tfsdk:"uniquename"`
+	Managed            types.Bool   `tfsdk:"managed"`
+	Isvm               types.Bool   `tfsdk:"isvm"`
+	HostID             types.String `tfsdk:"host_id"`
+	Hostname           types.String `tfsdk:"hostname"`
+	ApplianceID        types.String `tfsdk:"appliance_id"`
+	ApplianceClusterID types.String `tfsdk:"appliance_clusterid"`
+	SlaID              types.String `tfsdk:"sla_id"`
+}
+
+// NewApplicationAllDataSource - Datasource for Applications
+func NewApplicationAllDataSource() datasource.DataSource {
+	return &applicationAllDataSource{}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *applicationAllDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*backupdrProvider).client
+	d.authCtx = req.ProviderData.(*backupdrProvider).authCtx
+}
+
+func (d *applicationAllDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_applications"
+}
+
+func (d *applicationAllDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source can be used to read information about the applications discovered by the backup/recovery appliances, such as the ID to protect with a `backupdr_plan`. Every filter that is set must match.",
+		Attributes: listAttributes("applications", map[string]schema.Attribute{
+			"apptype": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Provide the application type to filter by, for example `GCPInstance` or `VMBackup`.",
+			},
+			"appname": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Provide the application name to filter by.",
+			},
+			"host": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Provide the ID, name or hostname of the host the applications belong to.",
+			},
+			"appliance": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Provide the ID, cluster ID or name of the backup/recovery appliance that discovered the applications.",
+			},
+			"uniquename": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Provide the unique name to filter by. For VMs this is the GCP instance ID or VMware VM UUID.",
+			},
+			"managed": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Set to true to only list applications protected by a backup plan, or false to only list unprotected ones.",
+			},
+			"items": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: applicationItemAttributes(),
+				},
+			},
+		}),
+	}
+}
+
+func (d *applicationAllDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !checkProviderConfigured(d.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state allApplicationsResourceModel
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts, ok := newListOptions(state.Limit, state.Sort, state.Filter, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	filter := newApplicationFilter(state.Apptype, state.Appname, state.Host, state.Appliance, state.Uniquename, state.Managed)
+	applications, err := listApplications(d.client, d.authCtx, filter, opts)
+	if err != nil {
+		addConsoleError(&resp.Diagnostics,
+			"Unable to Read BackupDR Applications",
+			"Could not list applications matching "+filter.String()+".",
+			nil, err, nil,
+		)
+		return
+	}
+
+	// Map response body to model
+	state.Items = make([]applicationItemRestModel, 0, len(applications))
+	for _, application := range applications {
+		state.Items = append(state.Items, newApplicationItemRestModel(application))
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// applicationItemAttributes returns the schema of an application listed by
+// backupdr_applications.
+func applicationItemAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "It displays the ID of the application.",
+		},
+		"href": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "It displays the URL to access the application in the management console.",
+		},
+		"stale": schema.BoolAttribute{
+			Computed:            true,
+			MarkdownDescription: "It displays the possible values true or false.",
+		},
+		"appname": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "It displays the name of the application.",
+		},
+		"apptype": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "It displays the application type.",
+		},
+		"friendlytype": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "It displays the application type as shown in the management console.",
+		},
+		"uniquename": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "It displays the unique name of the application.",
+		},
+		"managed": schema.BoolAttribute{
+			Computed:            true,
+			MarkdownDescription: "It displays whether the application is protected by a backup plan.",
+		},
+		"isvm": schema.BoolAttribute{
+			Computed:            true,
+			MarkdownDescription: "It displays whether the application is a VM.",
+		},
+		"host_id": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "It displays the ID of the host the application belongs to.",
+		},
+		"hostname": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "It displays the hostname of the host the application belongs to.",
+		},
+		"appliance_id": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "It displays the ID of the backup/recovery appliance that discovered the application.",
+		},
+		"appliance_clusterid": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "It displays the cluster ID of the backup/recovery appliance that discovered the application.",
+		},
+		"sla_id": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "It displays the ID of the backup plan protecting the application, if any.",
+		},
+	}
+}
+
+// newApplicationFilter builds the console filter from the data source
+// configuration.
+func newApplicationFilter(apptype, appname, host, appliance, uniquename types.String, managed types.Bool) applicationFilter {
+	filter := applicationFilter{
+		Apptype:    apptype.ValueString(),
+		Appname:    appname.ValueString(),
+		Host:       host.ValueString(),
+		Appliance:  appliance.ValueString(),
+		Uniquename: uniquename.ValueString(),
+	}
+	if !managed.IsNull() && !managed.IsUnknown() {
+		isManaged := managed.ValueBool()
+		filter.Managed = &isManaged
+	}
+	return filter
+}
+
+func newApplicationItemRestModel(application backupdr.ApplicationRest) applicationItemRestModel {
+	item := applicationItemRestModel{
+		ID:                 types.StringValue(application.Id),
+		Href:               types.StringValue(application.Href),
+		Stale:              types.BoolValue(application.Stale),
+		Appname:            types.StringValue(application.Appname),
+		Apptype:            types.StringValue(application.Apptype),
+		Friendlytype:       types.StringValue(application.Friendlytype),
+		Uniquename:         types.StringValue(application.Uniquename),
+		Managed:            types.BoolValue(application.Managed),
+		Isvm:               types.BoolValue(application.Isvm),
+		HostID:             types.StringNull(),
+		Hostname:           types.StringNull(),
+		ApplianceID:        types.StringNull(),
+		ApplianceClusterID: types.StringNull(),
+		SlaID:              types.StringNull(),
+	}
+	if application.Host != nil {
+		item.HostID = types.StringValue(application.Host.Id)
+		item.Hostname = types.StringValue(application.Host.Hostname)
+	}
+	if application.Cluster != nil {
+		item.ApplianceID = types.StringValue(application.Cluster.Id)
+		item.ApplianceClusterID = types.StringValue(application.Cluster.Clusterid)
+	}
+	if application.Sla != nil {
+		item.SlaID = types.StringValue(application.Sla.Id)
+	}
+	return item
+}
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccApplicationsDataSource(t *testing.T) {
+	console := newFakeConsole(t)
+	console.Seed("application", map[string]any{"appname": "web-1", "apptype": "GCPInstance", "managed": true})
+	console.Seed("application", map[string]any{"appname": "web-2", "apptype": "GCPInstance", "managed": false})
+	console.Seed("application", map[string]any{"appname": "db-1", "apptype": "VMBackup", "managed": true})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(console) + `
+data "backupdr_applications" "all" {}
+
+data "backupdr_applications" "managed_instances" {
+  apptype = "GCPInstance"
+  managed = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backupdr_applications.all", "items.#", "3"),
+					resource.TestCheckResourceAttr("data.backupdr_applications.managed_instances", "items.#", "1"),
+					resource.TestCheckResourceAttr("data.backupdr_applications.managed_instances", "items.0.appname", "web-1"),
+				),
+			},
+		},
+	})
+}
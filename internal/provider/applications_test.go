@@ -0,0 +1,269 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	backupdr "github.com/umeshkumhar/backupdr-client"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+func TestDiffVMs(t *testing.T) {
+	prior := []types.String{types.StringValue("vm-1"), types.StringValue("vm-2"), types.StringValue("vm-3")}
+	planned := []types.String{types.StringValue("vm-3"), types.StringValue("vm-4"), types.StringValue("vm-1")}
+
+	added, removed := diffVMs(prior, planned)
+	if !reflect.DeepEqual(added, []string{"vm-4"}) {
+		t.Errorf("got added %v, want [vm-4]", added)
+	}
+	if !reflect.DeepEqual(removed, []string{"vm-2"}) {
+		t.Errorf("got removed %v, want [vm-2]", removed)
+	}
+
+	added, removed = diffVMs(prior, prior)
+	if len(added) != 0 || len(removed) != 0 {
+		t.Errorf("got added %v and removed %v for an unchanged set", added, removed)
+	}
+}
+
+func TestUpgradeApplicationsToMap(t *testing.T) {
+	prior := `{"id":"a/v/c","vms":["vm-1","vm-2","vm-3"],"applications":["101","unknown-error","103"],"status":"200 OK"}`
+
+	req := resource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: []byte(prior)}}
+	resp := &resource.UpgradeStateResponse{}
+	upgradeApplicationsToMap("vms").StateUpgrader(context.Background(), req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	var upgraded struct {
+		VMs          []string          `json:"vms"`
+		Applications map[string]string `json:"applications"`
+		Status       string            `json:"status"`
+	}
+	if err := json.Unmarshal(resp.DynamicValue.JSON, &upgraded); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{"vm-1": "101", "vm-3": "103"}
+	if !reflect.DeepEqual(upgraded.Applications, want) {
+		t.Errorf("got applications %v, want %v", upgraded.Applications, want)
+	}
+	if len(upgraded.VMs) != 3 || upgraded.Status != "200 OK" {
+		t.Errorf("other attributes were not preserved: %+v", upgraded)
+	}
+}
+
+// discoveringConsole lists each VM as an application once the applications
+// have been listed a given number of times.
+type discoveringConsole struct {
+	mu       sync.Mutex
+	listings int
+	register map[string]int
+}
+
+func (c *discoveringConsole) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.listings++
+	var items []string
+	for _, vm := range []string{"vm-1", "vm-2", "vm-3"} {
+		if after, ok := c.register[vm]; ok && c.listings > after {
+			items = append(items, fmt.Sprintf(`{"id":"app-%s","uniquename":"%s"}`, vm, vm))
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintf(w, `{"items":[%s],"count":%d}`, strings.Join(items, ","), len(items))
+}
+
+func TestWaitForApplications(t *testing.T) {
+	defer func(interval time.Duration) { applicationPollInterval = interval }(applicationPollInterval)
+	applicationPollInterval = 10 * time.Millisecond
+
+	console := &discoveringConsole{register: map[string]int{"vm-1": 0, "vm-2": 2}}
+	srv := httptest.NewServer(console)
+	defer srv.Close()
+
+	cfg := backupdr.NewConfiguration()
+	cfg.Host = srv.URL
+	client := backupdr.NewAPIClient(cfg)
+
+	resolved, unresolved, err := waitForApplications(context.Background(), newApplicationIndex(), client, context.Background(), []string{"vm-1", "vm-2", "vm-3"}, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{"vm-1": "app-vm-1", "vm-2": "app-vm-2"}
+	if !reflect.DeepEqual(resolved, want) {
+		t.Errorf("got resolved %v, want %v", resolved, want)
+	}
+	if !reflect.DeepEqual(unresolved, []string{"vm-3"}) {
+		t.Errorf("got unresolved %v, want [vm-3]", unresolved)
+	}
+	if console.listings < 3 {
+		t.Errorf("got %d listings, want one per poll until vm-2 was registered", console.listings)
+	}
+}
+
+// pagedConsole serves total applications, honouring limit and offset, and
+// records the offset of every request.
+type pagedConsole struct {
+	mu      sync.Mutex
+	total   int
+	offsets []int
+}
+
+func (c *pagedConsole) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	c.offsets = append(c.offsets, offset)
+
+	var items []string
+	for i := offset; i < c.total && i < offset+limit; i++ {
+		if i%2 == 0 {
+			items = append(items, fmt.Sprintf(`{"id":"app-%d","uniquename":"vm-%d"}`, i, i))
+		} else {
+			items = append(items, fmt.Sprintf(`{"id":"app-%d","host":{"uniquename":"vm-%d"}}`, i, i))
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintf(w, `{"items":[%s],"count":%d}`, strings.Join(items, ","), c.total)
+}
+
+func TestApplicationIndex(t *testing.T) {
+	defer func(size int64) { listPageSize = size }(listPageSize)
+	listPageSize = 2
+
+	console := &pagedConsole{total: 5}
+	srv := httptest.NewServer(console)
+	defer srv.Close()
+
+	cfg := backupdr.NewConfiguration()
+	cfg.Host = srv.URL
+	client := backupdr.NewAPIClient(cfg)
+
+	index := newApplicationIndex()
+	applications, err := index.Resolve(client, context.Background(), []string{"vm-0", "vm-3", "vm-4", "vm-9"}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]string{}
+	for vm, application := range applications {
+		got[vm] = application.Id
+	}
+	want := map[string]string{"vm-0": "app-0", "vm-3": "app-3", "vm-4": "app-4"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got applications %v, want %v", got, want)
+	}
+	if !reflect.DeepEqual(console.offsets, []int{0, 2, 4}) {
+		t.Errorf("got offsets %v, want [0 2 4]", console.offsets)
+	}
+
+	// Later resources reuse the listing unless they need a fresher one.
+	if _, err := index.Resolve(client, context.Background(), []string{"vm-1"}, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(console.offsets) != 3 {
+		t.Errorf("got %d requests, want the listing to be reused", len(console.offsets))
+	}
+	if _, err := index.Resolve(client, context.Background(), []string{"vm-1"}, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if len(console.offsets) != 6 {
+		t.Errorf("got %d requests, want the applications to be listed again", len(console.offsets))
+	}
+}
+
+func TestApplicationFilter(t *testing.T) {
+	managed := true
+	filter := applicationFilter{
+		Apptype:   "GCPInstance",
+		Host:      "web-1",
+		Appliance: "144292692833",
+		Managed:   &managed,
+	}
+
+	if got, want := filter.expressions(), []string{"apptype:==GCPInstance", "managed:==true"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got expressions %v, want %v", got, want)
+	}
+
+	application := backupdr.ApplicationRest{
+		Apptype: "GCPInstance",
+		Managed: true,
+		Host:    &backupdr.HostRest{Id: "4501", Hostname: "web-1"},
+		Cluster: &backupdr.ClusterRest{Id: "1415", Clusterid: "144292692833"},
+	}
+	if !filter.matches(application) {
+		t.Error("expected the application to match")
+	}
+
+	application.Managed = false
+	if filter.matches(application) {
+		t.Error("unmanaged application matched managed = true")
+	}
+
+	application.Managed = true
+	application.Host = nil
+	if filter.matches(application) {
+		t.Error("application without a host matched the host filter")
+	}
+
+	if !(applicationFilter{}).matches(application) {
+		t.Error("an empty filter should match every application")
+	}
+}
+
+func TestListApplications_filters(t *testing.T) {
+	console := newFakeConsole(t)
+	console.Seed("application", map[string]any{"id": "1", "appname": "sales & marketing", "apptype": "SqlInstance"})
+	console.Seed("application", map[string]any{"id": "2", "appname": "sales & marketing", "apptype": "VMBackup"})
+	console.Seed("application", map[string]any{"id": "3", "appname": "db:prod", "apptype": "SqlInstance"})
+
+	recorder := &filterRecorder{}
+	cfg := backupdr.NewConfiguration()
+	cfg.Host = console.URL()
+	cfg.AddDefaultHeader(sessionHeader, sessionPrefix+" fake-session")
+	cfg.HTTPClient = &http.Client{Transport: &queryTransport{next: recorder}}
+	client := backupdr.NewAPIClient(cfg)
+
+	for appname, want := range map[string]string{"sales & marketing": "1", "db:prod": "3"} {
+		recorder.filters = nil
+		applications, err := listApplications(client, context.Background(), applicationFilter{Appname: appname, Apptype: "SqlInstance"}, listOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(applications) != 1 || applications[0].Id != want {
+			t.Errorf("got %+v for appname %q, want application %s", applications, appname, want)
+		}
+		want := []string{"appname:==" + appname, "apptype:==SqlInstance"}
+		if len(recorder.filters) != 1 || !reflect.DeepEqual(recorder.filters[0], want) {
+			t.Errorf("got filters %q for appname %q, want %q encoded once", recorder.filters, appname, want)
+		}
+	}
+}
+
+// filterRecorder records the filter parameters of every request it sends.
+type filterRecorder struct {
+	filters [][]string
+}
+
+func (r *filterRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.filters = append(r.filters, req.URL.Query()["filter"])
+	return http.DefaultTransport.RoundTrip(req)
+}
@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+// defaultOAuthScopes are requested for every token the provider mints.
+var defaultOAuthScopes = []string{
+	"https://www.googleapis.com/auth/cloud-platform",
+	"https://www.googleapis.com/auth/userinfo.email",
+}
+
+// iamCredentialsEndpoint is the base URL of the IAM Credentials API used for
+// service account impersonation. It is a variable so tests can point it to a
+// local fake token endpoint.
+var iamCredentialsEndpoint = "https://iamcredentials.googleapis.com/v1"
+
+// tokenSourceConfig holds the provider settings used to source OAuth tokens.
+type tokenSourceConfig struct {
+	AccessToken               string
+	Credentials               string
+	ImpersonateServiceAccount string
+	Scopes                    []string
+}
+
+// newTokenSource builds a refreshing token source from, in order of
+// precedence, a static access token, a service account key (file path or JSON
+// contents) or Application Default Credentials. When an impersonation target
+// is set, the resulting source is used to mint tokens for that service account.
+func newTokenSource(ctx context.Context, cfg tokenSourceConfig) (oauth2.TokenSource, error) {
+	scopes := cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = defaultOAuthScopes
+	}
+
+	var base oauth2.TokenSource
+	switch {
+	case cfg.AccessToken != "":
+		base = oauth2.StaticTokenSource(&oauth2.Token{AccessToken: cfg.AccessToken})
+	case cfg.Credentials != "":
+		contents, err := readCredentials(cfg.Credentials)
+		if err != nil {
+			return nil, err
+		}
+		creds, err := google.CredentialsFromJSON(ctx, contents, scopes...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse credentials: %w", err)
+		}
+		base = creds.TokenSource
+	default:
+		creds, err := google.FindDefaultCredentials(ctx, scopes...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to find Application Default Credentials: %w", err)
+		}
+		base = creds.TokenSource
+	}
+
+	if cfg.ImpersonateServiceAccount != "" {
+		base = &impersonatedTokenSource{
+			ctx:            ctx,
+			base:           oauth2.ReuseTokenSource(nil, base),
+			serviceAccount: cfg.ImpersonateServiceAccount,
+			scopes:         scopes,
+		}
+	}
+
+	return oauth2.ReuseTokenSource(nil, base), nil
+}
+
+// readCredentials returns the service account key contents, reading them from
+// disk when the value is a path rather than inline JSON.
+func readCredentials(credentials string) ([]byte, error) {
+	if strings.HasPrefix(strings.TrimSpace(credentials), "{") {
+		return []byte(credentials), nil
+	}
+	contents, err := os.ReadFile(credentials)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read credentials file %q: %w", credentials, err)
+	}
+	return contents, nil
+}
+
+// impersonatedTokenSource mints access tokens for a target service account
+// through the IAM Credentials generateAccessToken API.
+type impersonatedTokenSource struct {
+	ctx            context.Context
+	base           oauth2.TokenSource
+	serviceAccount string
+	scopes         []string
+}
+
+type generateAccessTokenRequest struct {
+	Scope    []string `json:"scope"`
+	Lifetime string   `json:"lifetime"`
+}
+
+type generateAccessTokenResponse struct {
+	AccessToken string    `json:"accessToken"`
+	ExpireTime  time.Time `json:"expireTime"`
+}
+
+// Token implements oauth2.TokenSource.
+func (s *impersonatedTokenSource) Token() (*oauth2.Token, error) {
+	body, err := json.Marshal(generateAccessTokenRequest{
+		Scope:    s.scopes,
+		Lifetime: "3600s",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/projects/-/serviceAccounts/%s:generateAccessToken", iamCredentialsEndpoint, s.serviceAccount)
+	req, err := http.NewRequestWithContext(s.ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := oauth2.NewClient(s.ctx, s.base).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to impersonate service account %s: %w", s.serviceAccount, err)
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to impersonate service account %s: %s: %s", s.serviceAccount, res.Status, resBody)
+	}
+
+	var token generateAccessTokenResponse
+	if err := json.Unmarshal(resBody, &token); err != nil {
+		return nil, fmt.Errorf("unable to parse impersonated token: %w", err)
+	}
+
+	return &oauth2.Token{
+		AccessToken: token.AccessToken,
+		TokenType:   "Bearer",
+		Expiry:      token.ExpireTime,
+	}, nil
+}
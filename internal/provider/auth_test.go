@@ -0,0 +1,166 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newFakeTokenServer returns a token endpoint issuing short-lived tokens,
+// numbered by the count of requests it has served.
+func newFakeTokenServer(t *testing.T, expiresIn int) (*httptest.Server, *int32) {
+	t.Helper()
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":%d}`, n, expiresIn)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func testServiceAccountJSON(t *testing.T, tokenURI string) string {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	contents, err := json.Marshal(map[string]string{
+		"type":           "service_account",
+		"project_id":     "test-project",
+		"private_key_id": "test-key",
+		"private_key":    string(keyPEM),
+		"client_email":   "terraform@test-project.iam.gserviceaccount.com",
+		"token_uri":      tokenURI,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(contents)
+}
+
+func TestNewTokenSource_staticAccessToken(t *testing.T) {
+	ts, err := newTokenSource(context.Background(), tokenSourceConfig{AccessToken: "static"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tok, err := ts.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tok.AccessToken != "static" {
+		t.Errorf("got token %q, want %q", tok.AccessToken, "static")
+	}
+}
+
+func TestNewTokenSource_serviceAccountRefresh(t *testing.T) {
+	// Tokens expiring within the oauth2 expiry delta are refreshed on every use.
+	srv, calls := newFakeTokenServer(t, 1)
+
+	ts, err := newTokenSource(context.Background(), tokenSourceConfig{
+		Credentials: testServiceAccountJSON(t, srv.URL),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := ts.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := ts.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first.AccessToken == second.AccessToken {
+		t.Errorf("expected a refreshed token, got %q twice", first.AccessToken)
+	}
+	if got := atomic.LoadInt32(calls); got != 2 {
+		t.Errorf("got %d token requests, want 2", got)
+	}
+}
+
+func TestNewTokenSource_serviceAccountReuse(t *testing.T) {
+	srv, calls := newFakeTokenServer(t, 3600)
+
+	ts, err := newTokenSource(context.Background(), tokenSourceConfig{
+		Credentials: testServiceAccountJSON(t, srv.URL),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := ts.Token(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := atomic.LoadInt32(calls); got != 1 {
+		t.Errorf("got %d token requests, want 1", got)
+	}
+}
+
+func TestNewTokenSource_impersonation(t *testing.T) {
+	var gotAuth, gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		gotPath = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"accessToken":"impersonated","expireTime":%q}`, time.Now().Add(time.Hour).Format(time.RFC3339))
+	}))
+	defer srv.Close()
+
+	old := iamCredentialsEndpoint
+	iamCredentialsEndpoint = srv.URL
+	defer func() { iamCredentialsEndpoint = old }()
+
+	ts, err := newTokenSource(context.Background(), tokenSourceConfig{
+		AccessToken:               "base",
+		ImpersonateServiceAccount: "target@test-project.iam.gserviceaccount.com",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tok, err := ts.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tok.AccessToken != "impersonated" {
+		t.Errorf("got token %q, want %q", tok.AccessToken, "impersonated")
+	}
+	if gotAuth != "Bearer base" {
+		t.Errorf("got Authorization %q, want %q", gotAuth, "Bearer base")
+	}
+	if !strings.HasSuffix(gotPath, "target@test-project.iam.gserviceaccount.com:generateAccessToken") {
+		t.Errorf("unexpected impersonation path %q", gotPath)
+	}
+}
+
+func TestReadCredentials(t *testing.T) {
+	if _, err := readCredentials("/does/not/exist.json"); err == nil {
+		t.Error("expected an error for a missing credentials file")
+	}
+	contents, err := readCredentials(`{"type":"service_account"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(contents), "service_account") {
+		t.Errorf("inline JSON was not returned as-is: %s", contents)
+	}
+}
@@ -2,11 +2,13 @@ package provider
 
 import (
 	"context"
+	"fmt"
 
 	backupdr "github.com/umeshkumhar/backupdr-client"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -65,8 +67,9 @@ func (d *cloudCredentialDataSource) Schema(ctx context.Context, req datasource.S
 		MarkdownDescription: "This data source can be used to read information about a BackupDR Cloud Credential. It displays the cloud credential ID as shown in the **Management console** > **Manage** > **Cloud Credentials** page.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "Provide the ID of the cloud credentials.",
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Provide the ID of the cloud credentials. Either `id` or `name` must be set.",
 			},
 			"href": schema.StringAttribute{
 				Computed:            true,
@@ -77,8 +80,9 @@ func (d *cloudCredentialDataSource) Schema(ctx context.Context, req datasource.S
 				MarkdownDescription: "It displays true or false if the data is synchronized with the management console or not.",
 			},
 			"clusterid": schema.Int64Attribute{
+				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "It displays the backup/recovery appliance ID as shown in the Management console > Manage > Appliances page.",
+				MarkdownDescription: "Provide the backup/recovery appliance ID to restrict a lookup by `name` to that appliance. It displays the backup/recovery appliance ID as shown in the Management console > Manage > Appliances page.",
 			},
 			"serviceaccount": schema.StringAttribute{
 				Computed:            true,
@@ -101,8 +105,9 @@ func (d *cloudCredentialDataSource) Schema(ctx context.Context, req datasource.S
 				MarkdownDescription: "It displays the domain associated with the cloud credential.",
 			},
 			"name": schema.StringAttribute{
+				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "It displays the name of the cloud credential.",
+				MarkdownDescription: "Provide the name of the cloud credential to look it up instead of `id`. It displays the name of the cloud credential.",
 			},
 			"endpoint": schema.StringAttribute{
 				Computed:            true,
@@ -130,15 +135,31 @@ func (d *cloudCredentialDataSource) Schema(ctx context.Context, req datasource.S
 
 func (d *cloudCredentialDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 
+	if !checkProviderConfigured(d.client, &resp.Diagnostics) {
+		return
+	}
+
 	var state cloudCredentialResourceModel
 	// Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if !checkLookupKeys(state.ID, state.Name, "name", &resp.Diagnostics) {
+		return
+	}
+
+	credentialID := state.ID.ValueString()
+	if credentialID == "" {
+		var ok bool
+		if credentialID, ok = d.lookupID(state, &resp.Diagnostics); !ok {
+			return
+		}
+	}
 
-	cc, res, err := d.client.DefaultApi.GetCredential(d.authCtx, state.ID.ValueString())
+	cc, res, err := d.client.DefaultApi.GetCredential(d.authCtx, credentialID)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addConsoleError(&resp.Diagnostics,
 			"Unable to Read CloudCredential",
-			err.Error(),
+			"Could not read cloud credential with ID "+credentialID+".",
+			res, err, nil,
 		)
 		return
 	}
@@ -175,3 +196,34 @@ func (d *cloudCredentialDataSource) Read(ctx context.Context, req datasource.Rea
 		return
 	}
 }
+
+// lookupID resolves the cloud credential ID from its name, optionally
+// restricted to one appliance. The list API takes no filter, so the names are
+// compared locally.
+func (d *cloudCredentialDataSource) lookupID(state cloudCredentialResourceModel, diags *diag.Diagnostics) (string, bool) {
+	name := state.Name.ValueString()
+	credentials, res, err := d.client.DefaultApi.ListCredentials(d.authCtx)
+	if err != nil {
+		addConsoleError(diags,
+			"Unable to Read CloudCredential",
+			"Could not list cloud credentials.",
+			res, err, nil,
+		)
+		return "", false
+	}
+
+	description := fmt.Sprintf("name %q", name)
+	scoped := !state.ClusterID.IsNull() && !state.ClusterID.IsUnknown()
+	if scoped {
+		description += fmt.Sprintf(" on appliance %d", state.ClusterID.ValueInt64())
+	}
+
+	var candidates []lookupCandidate
+	for _, cc := range credentials.Items {
+		if cc.Name != name || (scoped && cc.ClusterId != state.ClusterID.ValueInt64()) {
+			continue
+		}
+		candidates = append(candidates, lookupCandidate{ID: cc.Id, Name: cc.Name})
+	}
+	return resolveLookupID("Cloud Credential", description, candidates, diags)
+}
@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCloudcredentialDataSource(t *testing.T) {
+	console := newFakeConsole(t)
+	credentialID := console.Seed("cloudcredential", map[string]any{
+		"name":           "backup-sa",
+		"clusterId":      144292692833,
+		"projectid":      "my-project",
+		"region":         "us-central1",
+		"serviceaccount": "backup@my-project.iam.gserviceaccount.com",
+	})
+	console.Seed("cloudcredential", map[string]any{"name": "backup-sa", "clusterId": 144292692834})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(console) + `
+data "backupdr_cloudcredential" "by_id" {
+  id = "` + credentialID + `"
+}
+
+data "backupdr_cloudcredential" "by_name" {
+  name      = "backup-sa"
+  clusterid = 144292692833
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backupdr_cloudcredential.by_id", "projectid", "my-project"),
+					resource.TestCheckResourceAttr("data.backupdr_cloudcredential.by_id", "clusterid", "144292692833"),
+					resource.TestCheckResourceAttr("data.backupdr_cloudcredential.by_name", "id", credentialID),
+				),
+			},
+		},
+	})
+}
@@ -24,7 +24,10 @@ type cloudcredentialAllDataSource struct {
 
 // tf go model
 type allCloudCredentialsResourceModel struct {
-	Items []cloudCredentialResourceModel `tfsdk:"items"`
+	Limit  types.Int64                    `tfsdk:"limit"`
+	Sort   types.String                   `tfsdk:"sort"`
+	Filter types.String                   `tfsdk:"filter"`
+	Items  []cloudCredentialResourceModel `tfsdk:"items"`
 }
 
 // NewCloudcredentialAllDataSource - Datasource for CloudCredentials
@@ -49,7 +52,7 @@ func (d *cloudcredentialAllDataSource) Metadata(ctx context.Context, req datasou
 func (d *cloudcredentialAllDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "This data source can be used to read information about all BackupDR Cloud Credentials. It displays the cloud credential ID as shown in the **Management console** > **Manage** > **Cloud Credentials** page.",
-		Attributes: map[string]schema.Attribute{
+		Attributes: listAttributes("cloud credentials", map[string]schema.Attribute{
 			"items": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -117,35 +120,48 @@ func (d *cloudcredentialAllDataSource) Schema(ctx context.Context, req datasourc
 					},
 				},
 			},
-		},
+		}),
 	}
 }
 
 func (d *cloudcredentialAllDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 
+	if !checkProviderConfigured(d.client, &resp.Diagnostics) {
+		return
+	}
+
 	var state allCloudCredentialsResourceModel
 	// Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
-
-	ccs, res, err := d.client.DefaultApi.ListCredentials(d.authCtx)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Read BackupDR CloudCredentials",
-			err.Error(),
-		)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	opts, ok := newListOptions(state.Limit, state.Sort, state.Filter, &resp.Diagnostics)
+	if !ok {
 		return
 	}
 
-	if res.StatusCode != 200 {
-		resp.Diagnostics.AddError(
+	// The client does not take paging options for cloud credentials, so
+	// they are added to the query through the context.
+	ccs, err := listAll(opts.Limit, func(limit, offset int64) ([]backupdr.CloudCredentialRest, int32, error) {
+		page, res, err := d.client.DefaultApi.ListCredentials(withQueryParams(d.authCtx, pageParams(opts, limit, offset)))
+		if err != nil {
+			return nil, 0, newRequestError(res, err)
+		}
+		return page.Items, page.Count, nil
+	}, nil)
+	if err != nil {
+		addConsoleError(&resp.Diagnostics,
 			"Unable to Read BackupDR CloudCredentials",
-			res.Status,
+			"Could not list cloud credentials.",
+			nil, err, nil,
 		)
+		return
 	}
 
 	var finalList = []cloudCredentialResourceModel{}
 	// Map response body to model
-	for _, cc := range ccs.Items {
+	for _, cc := range ccs {
 		ccState := cloudCredentialResourceModel{
 			ID:             types.StringValue(cc.Id),
 			Href:           types.StringValue(cc.Href),
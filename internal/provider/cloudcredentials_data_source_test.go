@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCloudcredentialsDataSource(t *testing.T) {
+	console := newFakeConsole(t)
+	console.Seed("cloudcredential", map[string]any{"name": "backup-sa-1", "projectid": "project-1"})
+	console.Seed("cloudcredential", map[string]any{"name": "backup-sa-2", "projectid": "project-2"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(console) + `
+data "backupdr_cloudcredentials" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backupdr_cloudcredentials.test", "items.#", "2"),
+					resource.TestCheckResourceAttr("data.backupdr_cloudcredentials.test", "items.0.name", "backup-sa-1"),
+					resource.TestCheckResourceAttr("data.backupdr_cloudcredentials.test", "items.1.projectid", "project-2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudcredentialsDataSource_pages(t *testing.T) {
+	defer func(size int64) { listPageSize = size }(listPageSize)
+	listPageSize = 1
+
+	console := newFakeConsole(t)
+	for _, name := range []string{"backup-sa-1", "backup-sa-2", "backup-sa-3"} {
+		console.Seed("cloudcredential", map[string]any{"name": name})
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(console) + `
+data "backupdr_cloudcredentials" "all" {}
+
+data "backupdr_cloudcredentials" "limited" {
+  limit = 2
+}
+
+data "backupdr_cloudcredentials" "filtered" {
+  filter = "name:==backup-sa-3"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backupdr_cloudcredentials.all", "items.#", "3"),
+					resource.TestCheckResourceAttr("data.backupdr_cloudcredentials.all", "items.2.name", "backup-sa-3"),
+					resource.TestCheckResourceAttr("data.backupdr_cloudcredentials.limited", "items.#", "2"),
+					resource.TestCheckResourceAttr("data.backupdr_cloudcredentials.filtered", "items.#", "1"),
+					resource.TestCheckResourceAttr("data.backupdr_cloudcredentials.filtered", "items.0.name", "backup-sa-3"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,507 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	backupdr "github.com/umeshkumhar/backupdr-client"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// fakeConsole is an in-memory management console serving the part of the
+// API used by the provider. Objects are kept as decoded JSON, keyed by
+// collection path (such as "slt" or "slt/1001/policy") and ID, so tests can
+// seed fixtures and change objects behind Terraform's back to simulate drift.
+type fakeConsole struct {
+	server *httptest.Server
+
+	mu      sync.Mutex
+	nextID  int
+	objects map[string]map[string]map[string]any
+	// maxPage caps the number of objects of a list page, like consoles
+	// with a page limit below the one requested. Zero leaves pages uncapped.
+	maxPage int
+}
+
+// newFakeConsole starts a fake console that is shut down with the test.
+func newFakeConsole(t *testing.T) *fakeConsole {
+	t.Helper()
+
+	c := &fakeConsole{
+		nextID:  1000,
+		objects: map[string]map[string]map[string]any{},
+	}
+	c.server = httptest.NewServer(c)
+	t.Cleanup(c.server.Close)
+	return c
+}
+
+// URL returns the endpoint to configure the provider with.
+func (c *fakeConsole) URL() string {
+	return c.server.URL
+}
+
+// Config returns a client configuration of the console with a session, for
+// tests calling the resources directly.
+func (c *fakeConsole) Config() *backupdr.Configuration {
+	cfg := backupdr.NewConfiguration()
+	cfg.Host = c.server.URL
+	cfg.AddDefaultHeader(sessionHeader, sessionPrefix+" fake-session")
+	return cfg
+}
+
+// Client returns a client of the console with a session.
+func (c *fakeConsole) Client() *backupdr.APIClient {
+	return backupdr.NewAPIClient(c.Config())
+}
+
+// Seed stores an object in collection and returns its ID. The object keeps
+// its own "id" when it has one.
+func (c *fakeConsole) Seed(collection string, object map[string]any) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.create(collection, object)
+}
+
+// Get returns a copy of a stored object, or nil.
+func (c *fakeConsole) Get(collection, id string) map[string]any {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	object, ok := c.objects[collection][id]
+	if !ok {
+		return nil
+	}
+	return copyObject(object)
+}
+
+// Update changes fields of a stored object.
+func (c *fakeConsole) Update(collection, id string, fields map[string]any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for k, v := range fields {
+		c.objects[collection][id][k] = v
+	}
+}
+
+// Delete removes a stored object.
+func (c *fakeConsole) Delete(collection, id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.objects[collection], id)
+}
+
+// Find returns the ID of the first object in collection whose field has the
+// given value, or "".
+func (c *fakeConsole) Find(collection, field, value string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, id := range c.ids(collection) {
+		if fieldString(c.objects[collection][id], field) == value {
+			return id
+		}
+	}
+	return ""
+}
+
+// Count returns the number of objects in collection.
+func (c *fakeConsole) Count(collection string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.objects[collection])
+}
+
+// ServeHTTP implements http.Handler.
+func (c *fakeConsole) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/actifio"), "/"), "/")
+
+	var body map[string]any
+	if r.Body != nil {
+		data, _ := io.ReadAll(r.Body)
+		if len(data) > 0 {
+			if err := json.Unmarshal(data, &body); err != nil {
+				c.fail(w, http.StatusBadRequest, 10001, "malformed request body: "+err.Error())
+				return
+			}
+		}
+	}
+
+	switch {
+	case r.Method == http.MethodPost && segments[0] == "session" && len(segments) == 1:
+		c.write(w, http.StatusOK, map[string]any{"session_id": "fake-session"})
+		return
+	case r.Method == http.MethodDelete && segments[0] == "session":
+		w.WriteHeader(http.StatusNoContent)
+		return
+	case r.Header.Get(sessionHeader) == "":
+		c.fail(w, http.StatusUnauthorized, 10011, "missing session")
+		return
+	case r.Method == http.MethodPost && len(segments) == 4 && segments[0] == "cloudcredential" && segments[3] == "addvm":
+		c.discoverCloudVMs(w, body)
+		return
+	case r.Method == http.MethodPost && len(segments) == 5 && segments[0] == "host" && segments[4] == "addvms":
+		c.discoverVcenterVMs(w, segments[1], body)
+		return
+	}
+
+	if len(segments)%2 == 1 {
+		collection := strings.Join(segments, "/")
+		switch r.Method {
+		case http.MethodGet:
+			c.list(w, r, collection)
+		case http.MethodPost:
+			if body == nil {
+				body = map[string]any{}
+			}
+			id := c.create(collection, body)
+			switch collection {
+			case "sla":
+				c.protect(body, id)
+			case "slt":
+				c.createPolicies(id)
+			}
+			c.write(w, http.StatusOK, c.view(collection, c.objects[collection][id]))
+		default:
+			c.fail(w, http.StatusMethodNotAllowed, 10001, r.Method+" is not supported on "+collection)
+		}
+		return
+	}
+
+	collection := strings.Join(segments[:len(segments)-1], "/")
+	id := segments[len(segments)-1]
+	object, ok := c.objects[collection][id]
+	if !ok {
+		c.fail(w, http.StatusNotFound, 10006, fmt.Sprintf("%s %s not found", collection, id))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		c.write(w, http.StatusOK, c.view(collection, object))
+	case http.MethodPut:
+		for k, v := range body {
+			if k != "id" && k != "href" {
+				object[k] = v
+			}
+		}
+		c.write(w, http.StatusOK, c.view(collection, object))
+	case http.MethodDelete:
+		if collection == "sla" {
+			c.unprotect(id)
+		}
+		delete(c.objects[collection], id)
+		for nested := range c.objects {
+			if strings.HasPrefix(nested, collection+"/"+id+"/") {
+				delete(c.objects, nested)
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		c.fail(w, http.StatusMethodNotAllowed, 10001, r.Method+" is not supported on "+collection)
+	}
+}
+
+// create stores object under a new ID. Callers must hold c.mu.
+func (c *fakeConsole) create(collection string, object map[string]any) string {
+	id, _ := object["id"].(string)
+	if id == "" {
+		c.nextID++
+		id = strconv.Itoa(c.nextID)
+	}
+
+	object = copyObject(object)
+	fillDefaults(collection, object)
+	object["id"] = id
+	object["href"] = c.server.URL + "/actifio/" + collection + "/" + id
+	if c.objects[collection] == nil {
+		c.objects[collection] = map[string]map[string]any{}
+	}
+	c.objects[collection][id] = object
+	return id
+}
+
+// list writes the objects of collection that match every "field:==value"
+// filter, if any. Callers must hold c.mu.
+func (c *fakeConsole) list(w http.ResponseWriter, r *http.Request, collection string) {
+	filters := map[string]string{}
+	for _, filter := range r.URL.Query()["filter"] {
+		if field, value, ok := strings.Cut(filter, ":=="); ok {
+			filters[field] = value
+		}
+	}
+
+	items := []map[string]any{}
+	for _, id := range c.ids(collection) {
+		object := c.objects[collection][id]
+		matched := true
+		for field, value := range filters {
+			matched = matched && fieldString(object, field) == value
+		}
+		if matched {
+			items = append(items, c.view(collection, object))
+		}
+	}
+	count := len(items)
+	if offset, err := strconv.Atoi(r.URL.Query().Get("offset")); err == nil {
+		if offset > len(items) {
+			offset = len(items)
+		}
+		items = items[offset:]
+	}
+	if limit, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && limit < len(items) {
+		items = items[:limit]
+	}
+	if c.maxPage > 0 && c.maxPage < len(items) {
+		items = items[:c.maxPage]
+	}
+	c.write(w, http.StatusOK, map[string]any{"items": items, "count": count})
+}
+
+// discoverCloudVMs registers each requested Compute Engine VM as an
+// application. Callers must hold c.mu.
+func (c *fakeConsole) discoverCloudVMs(w http.ResponseWriter, body map[string]any) {
+	cluster, _ := body["cluster"].(map[string]any)
+	vmIDs, _ := body["vmids"].([]any)
+	for _, vm := range vmIDs {
+		c.registerApplication(fmt.Sprint(vm), "GCPInstance", map[string]any{"clusterid": cluster["clusterid"]}, nil)
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// discoverVcenterVMs registers each requested vCenter VM as an application.
+// Callers must hold c.mu.
+func (c *fakeConsole) discoverVcenterVMs(w http.ResponseWriter, hostID string, body map[string]any) {
+	vms, _ := body["vms"].([]any)
+	for _, vm := range vms {
+		c.registerApplication(fmt.Sprint(vm), "VMBackup", map[string]any{"id": body["cluster"]}, map[string]any{"id": hostID})
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// registerApplication adds an application unless one with the same unique
+// name exists. Callers must hold c.mu.
+func (c *fakeConsole) registerApplication(uniquename, apptype string, cluster, host map[string]any) {
+	for _, application := range c.objects["application"] {
+		if application["uniquename"] == uniquename {
+			return
+		}
+	}
+
+	application := map[string]any{
+		"uniquename": uniquename,
+		"appname":    uniquename,
+		"apptype":    apptype,
+		"isvm":       true,
+		"cluster":    cluster,
+	}
+	if host != nil {
+		application["host"] = host
+	}
+	c.create("application", application)
+}
+
+// references maps the fields of backup plans that refer to other objects to
+// the collections of those objects.
+var references = map[string]string{
+	"application": "application",
+	"slp":         "slp",
+	"slt":         "slt",
+}
+
+// view returns object as the console returns it. Like the console, it
+// expands the references of a backup plan to the referenced objects.
+// Callers must hold c.mu.
+func (c *fakeConsole) view(collection string, object map[string]any) map[string]any {
+	if collection != "sla" {
+		return object
+	}
+
+	object = copyObject(object)
+	for field, referenced := range references {
+		ref, _ := object[field].(map[string]any)
+		if ref == nil {
+			continue
+		}
+		if stored, ok := c.objects[referenced][fmt.Sprint(ref["id"])]; ok {
+			object[field] = copyObject(stored)
+		}
+	}
+	return object
+}
+
+// createPolicies moves the policies of a new template to its policy
+// collection, since the console does not return them with the template.
+// Callers must hold c.mu.
+func (c *fakeConsole) createPolicies(sltID string) {
+	slt := c.objects["slt"][sltID]
+	policies, _ := slt["policies"].([]any)
+	delete(slt, "policies")
+	slt["policy_href"] = slt["href"].(string) + "/policy"
+	slt["option_href"] = slt["href"].(string) + "/option"
+
+	for _, policy := range policies {
+		if policy, ok := policy.(map[string]any); ok {
+			c.create("slt/"+sltID+"/policy", policy)
+		}
+	}
+}
+
+// protect links the application of a new backup plan to it. Callers must
+// hold c.mu.
+func (c *fakeConsole) protect(sla map[string]any, slaID string) {
+	application, _ := sla["application"].(map[string]any)
+	if application == nil {
+		return
+	}
+	if stored, ok := c.objects["application"][fmt.Sprint(application["id"])]; ok {
+		stored["sla"] = map[string]any{"id": slaID}
+		stored["managed"] = true
+	}
+}
+
+// unprotect removes a deleted backup plan from its application. Callers must
+// hold c.mu.
+func (c *fakeConsole) unprotect(slaID string) {
+	for _, application := range c.objects["application"] {
+		if sla, _ := application["sla"].(map[string]any); sla != nil && sla["id"] == slaID {
+			delete(application, "sla")
+			application["managed"] = false
+		}
+	}
+}
+
+// ids returns the IDs of collection in creation order. Callers must hold
+// c.mu.
+func (c *fakeConsole) ids(collection string) []string {
+	ids := make([]string, 0, len(c.objects[collection]))
+	for id := range c.objects[collection] {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		a, errA := strconv.Atoi(ids[i])
+		b, errB := strconv.Atoi(ids[j])
+		if errA != nil || errB != nil {
+			return ids[i] < ids[j]
+		}
+		return a < b
+	})
+	return ids
+}
+
+func (c *fakeConsole) write(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func (c *fakeConsole) fail(w http.ResponseWriter, status int, code int, message string) {
+	c.write(w, status, map[string]any{"err_code": code, "err_message": message})
+}
+
+// consoleDefaults are the values the console fills in for fields a new object
+// leaves out, keyed by the last segment of the collection path.
+var consoleDefaults = map[string]map[string]any{
+	"slp": {
+		"performancepool": defaultPerformancePool,
+		"localnode":       "appliance-1",
+		"cid":             "4711",
+	},
+	"sla": {
+		"scheduleoff": "false",
+	},
+	"slt": {
+		"override": "false",
+	},
+	"policy": {
+		"priority":          "medium",
+		"scheduletype":      "daily",
+		"selection":         "none",
+		"exclusion":         "none",
+		"exclusiontype":     "none",
+		"repeatinterval":    "1",
+		"exclusioninterval": "1",
+		"rpom":              "hours",
+		"retentionm":        "days",
+		"encrypt":           "secure",
+		"reptype":           "StreamSnap",
+		"truncatelog":       "false",
+		"verifychoice":      "empty",
+		"policyType":        "snapshot",
+	},
+}
+
+// fillDefaults sets the fields of a new object in collection that the
+// console defaults.
+func fillDefaults(collection string, object map[string]any) {
+	for field, value := range consoleDefaults[collection[strings.LastIndex(collection, "/")+1:]] {
+		if v, ok := object[field]; !ok || v == "" {
+			object[field] = value
+		}
+	}
+}
+
+// fieldString formats a top-level field of object for filter comparison.
+func fieldString(object map[string]any, field string) string {
+	v, ok := object[field]
+	if !ok || v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+// copyObject returns a deep copy of a decoded JSON object.
+func copyObject(object map[string]any) map[string]any {
+	data, _ := json.Marshal(object)
+	var out map[string]any
+	_ = json.Unmarshal(data, &out)
+	return out
+}
+
+// CheckField returns a check that the console object backing the Terraform
+// resource has the given top-level field value.
+func (c *fakeConsole) CheckField(collection, resourceName, field, value string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", resourceName)
+		}
+
+		object := c.Get(collection, rs.Primary.ID)
+		if object == nil {
+			return fmt.Errorf("%s %s not found in the console", collection, rs.Primary.ID)
+		}
+		if got := fieldString(object, field); got != value {
+			return fmt.Errorf("%s %s: got %s %q, want %q", collection, rs.Primary.ID, field, got, value)
+		}
+		return nil
+	}
+}
+
+// CheckDestroyed returns a check that the console no longer has objects in
+// collection.
+func (c *fakeConsole) CheckDestroyed(collection string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		if n := c.Count(collection); n != 0 {
+			return fmt.Errorf("%d %s objects left in the console", n, collection)
+		}
+		return nil
+	}
+}
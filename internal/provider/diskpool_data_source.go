@@ -2,11 +2,14 @@ package provider
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/antihax/optional"
 	backupdr "github.com/umeshkumhar/backupdr-client"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -46,12 +49,14 @@ func (d *diskpoolDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 		MarkdownDescription: "This data source can be used to read information about a Backup and DR service diskpool.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "Provide the ID of the storage pool.",
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Provide the ID of the storage pool. Either `id` or `name` must be set.",
 			},
 			"name": schema.StringAttribute{
+				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "It displays the name of the storage pool.",
+				MarkdownDescription: "Provide the name of the storage pool to look it up instead of `id`.",
 			},
 			"href": schema.StringAttribute{
 				Computed:            true,
@@ -149,8 +154,9 @@ func (d *diskpoolDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 				},
 			},
 			"appliance_clusterid": schema.StringAttribute{
+				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "It displays the backup/recovery appliance ID.",
+				MarkdownDescription: "Provide the backup/recovery appliance ID to restrict a lookup by `name` to that appliance. It displays the backup/recovery appliance ID.",
 			},
 			"cluster": schema.SingleNestedAttribute{
 				Computed:            true,
@@ -266,15 +272,31 @@ func (d *diskpoolDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 
 func (d *diskpoolDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 
-	var state diskPoolResourceModel
+	if !checkProviderConfigured(d.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state diskPoolDataSourceModel
 	// Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if !checkLookupKeys(state.ID, state.Name, "name", &resp.Diagnostics) {
+		return
+	}
 
-	diskpool, res, err := d.client.DiskPoolApi.GetDiskPool(d.authCtx, state.ID.ValueString())
+	diskpoolID := state.ID.ValueString()
+	if diskpoolID == "" {
+		var ok bool
+		if diskpoolID, ok = d.lookupID(state, &resp.Diagnostics); !ok {
+			return
+		}
+	}
+
+	diskpool, res, err := d.client.DiskPoolApi.GetDiskPool(d.authCtx, diskpoolID)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addConsoleError(&resp.Diagnostics,
 			"Unable to Read BackupDR DiskPool",
-			err.Error(),
+			"Could not read DiskPool with ID "+diskpoolID+".",
+			res, err, nil,
 		)
 		return
 	}
@@ -288,7 +310,7 @@ func (d *diskpoolDataSource) Read(ctx context.Context, req datasource.ReadReques
 	}
 
 	// Map response body to model
-	state = diskPoolResourceModel{
+	state = diskPoolDataSourceModel{
 		Name:                types.StringValue(diskpool.Name),
 		ID:                  types.StringValue(diskpool.Id),
 		Pooltype:            types.StringValue(diskpool.Pooltype),
@@ -361,3 +383,39 @@ func (d *diskpoolDataSource) Read(ctx context.Context, req datasource.ReadReques
 	}
 
 }
+
+// lookupID resolves the storage pool ID from its name, optionally restricted
+// to one appliance.
+func (d *diskpoolDataSource) lookupID(state diskPoolDataSourceModel, diags *diag.Diagnostics) (string, bool) {
+	name := state.Name.ValueString()
+	filter := backupdr.DiskPoolApiListDiskPoolsOpts{
+		Filter: optional.NewString("name:==" + name),
+	}
+	diskpools, res, err := d.client.DiskPoolApi.ListDiskPools(d.authCtx, &filter)
+	if err != nil {
+		addConsoleError(diags,
+			"Unable to Read BackupDR DiskPool",
+			"Could not list DiskPools named "+name+".",
+			res, err, nil,
+		)
+		return "", false
+	}
+
+	description := fmt.Sprintf("name %q", name)
+	clusterID := state.ApplianceClusterID.ValueString()
+	if clusterID != "" {
+		description += fmt.Sprintf(" on appliance %q", clusterID)
+	}
+
+	var candidates []lookupCandidate
+	for _, diskpool := range diskpools.Items {
+		if diskpool.Name != name {
+			continue
+		}
+		if clusterID != "" && (diskpool.Cluster == nil || diskpool.Cluster.Clusterid != clusterID) {
+			continue
+		}
+		candidates = append(candidates, lookupCandidate{ID: diskpool.Id, Name: diskpool.Name})
+	}
+	return resolveLookupID("Storage Pool", description, candidates, diags)
+}
@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDiskpoolDataSource(t *testing.T) {
+	console := newFakeConsole(t)
+	diskpoolID := console.Seed("diskpool", map[string]any{
+		"name":     "onvault-pool",
+		"pooltype": "vault",
+		"cluster":  map[string]any{"id": "1415", "clusterid": "144292692833", "name": "backup-appliance-1"},
+		"properties": []any{
+			map[string]any{"key": "bucket", "value": "test-bucket"},
+		},
+	})
+	console.Seed("diskpool", map[string]any{
+		"name":     "onvault-pool",
+		"pooltype": "vault",
+		"cluster":  map[string]any{"id": "1416", "clusterid": "144292692834"},
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(console) + `
+data "backupdr_diskpool" "by_id" {
+  id = "` + diskpoolID + `"
+}
+
+data "backupdr_diskpool" "by_name" {
+  name                = "onvault-pool"
+  appliance_clusterid = "144292692833"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backupdr_diskpool.by_id", "name", "onvault-pool"),
+					resource.TestCheckResourceAttr("data.backupdr_diskpool.by_id", "properties.0.value", "test-bucket"),
+					resource.TestCheckResourceAttr("data.backupdr_diskpool.by_name", "id", diskpoolID),
+				),
+			},
+		},
+	})
+}
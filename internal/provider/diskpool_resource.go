@@ -17,6 +17,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -26,6 +27,15 @@ var (
 	_ resource.ResourceWithImportState = &diskpoolResource{}
 )
 
+// diskpoolConsoleFields maps the fields of diskpool requests to the attributes
+// they are set from, so console errors are reported against them.
+var diskpoolConsoleFields = map[string]path.Path{
+	"name":       path.Root("name"),
+	"pooltype":   path.Root("pooltype"),
+	"properties": path.Root("properties"),
+	"cluster":    path.Root("appliance_clusterid"),
+}
+
 // NewDiskpoolResource to create DiskPool
 func NewDiskpoolResource() resource.Resource {
 	return &diskpoolResource{}
@@ -43,7 +53,7 @@ func (r *diskpoolResource) Metadata(_ context.Context, req resource.MetadataRequ
 }
 
 // Schema defines the schema for the resource.
-func (r *diskpoolResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *diskpoolResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Backup/recovery appliances store data in these types of pools: Primary, Cloud, OnVault, and Snapshot. Every backup/recovery appliance has one primary pool that contains metadata and log files for the backup/recovery appliance. No user data or backups are stored in the primary pool. \n" +
 			"Cloud type pools represent Cloud credentials used to back up Compute Engine instances. These pools are automatically created when a Cloud credential is created. They do not represent a pool of disks managed by the backup/recovery appliance.  \n" +
@@ -282,6 +292,9 @@ func (r *diskpoolResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": resourceTimeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -297,6 +310,10 @@ func (r *diskpoolResource) Configure(_ context.Context, req resource.ConfigureRe
 
 // Create a new resource.
 func (r *diskpoolResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !checkProviderConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	// Retrieve values from state
 	var state diskPoolResourceModel
 	diags := req.Plan.Get(ctx, &state)
@@ -305,6 +322,14 @@ func (r *diskpoolResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	createTimeout, diags := state.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, authCtx, cancel := withTimeout(ctx, r.authCtx, createTimeout)
+	defer cancel()
+
 	reqDiskpool := backupdr.DiskPoolRest{
 		Name:     state.Name.ValueString(),
 		Pooltype: state.Pooltype.ValueString(),
@@ -324,11 +349,12 @@ func (r *diskpoolResource) Create(ctx context.Context, req resource.CreateReques
 	}
 
 	// Create new diskpool
-	respObject, res, err := r.client.DiskPoolApi.CreateDiskPool(r.authCtx, &reqBody)
+	respObject, res, err := r.client.DiskPoolApi.CreateDiskPool(authCtx, &reqBody)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addConsoleError(&resp.Diagnostics,
 			"Error creating Diskpool",
-			"Could not create Diskpool, unexpected error: "+err.Error(),
+			"Could not create Diskpool.",
+			res, err, diskpoolConsoleFields,
 		)
 		return
 	}
@@ -411,6 +437,10 @@ func (r *diskpoolResource) Create(ctx context.Context, req resource.CreateReques
 
 // Read resource information.
 func (r *diskpoolResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if unknownProviderConfig(ctx, r.client) {
+		return
+	}
+
 	// Get current state
 	var state diskPoolResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -419,12 +449,26 @@ func (r *diskpoolResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, authCtx, cancel := withTimeout(ctx, r.authCtx, readTimeout)
+	defer cancel()
+
 	// Get refreshed values
-	respObject, res, err := r.client.DiskPoolApi.GetDiskPool(r.authCtx, state.ID.ValueString())
+	respObject, res, err := r.client.DiskPoolApi.GetDiskPool(authCtx, state.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
+		if isNotFound(res, err) {
+			tflog.Warn(ctx, "DiskPool no longer exists, removing it from state", map[string]any{"id": state.ID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addConsoleError(&resp.Diagnostics,
 			"Error Reading DiskPool",
-			"Could not read DiskPool with ID "+state.ID.ValueString()+": "+err.Error(),
+			"Could not read DiskPool with ID "+state.ID.ValueString()+".",
+			res, err, nil,
 		)
 		return
 	}
@@ -438,6 +482,19 @@ func (r *diskpoolResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 	// Overwrite items with refreshed state
 	state.ID = types.StringValue(respObject.Id)
+	state.Name = types.StringValue(respObject.Name)
+	state.Pooltype = types.StringValue(respObject.Pooltype)
+
+	// The console may add its own properties, so only take them over when
+	// there are none yet, such as after an import.
+	if state.Properties == nil {
+		for _, prop := range respObject.Properties {
+			state.Properties = append(state.Properties, keyValueRestModel{
+				Key:   types.StringValue(prop.Key),
+				Value: types.StringValue(prop.Value),
+			})
+		}
+	}
 	state.Href = types.StringValue(respObject.Href)
 	state.Syncdate = types.Int64Value(respObject.Syncdate)
 	state.Stale = types.BoolValue(respObject.Stale)
@@ -500,6 +557,10 @@ func (r *diskpoolResource) Read(ctx context.Context, req resource.ReadRequest, r
 }
 
 func (r *diskpoolResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !checkProviderConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	// Retrieve values from state
 	var state diskPoolResourceModel
 	diags := req.Plan.Get(ctx, &state)
@@ -508,6 +569,14 @@ func (r *diskpoolResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	updateTimeout, diags := state.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, authCtx, cancel := withTimeout(ctx, r.authCtx, updateTimeout)
+	defer cancel()
+
 	reqDiskpool := backupdr.DiskPoolRest{
 		Name:     state.Name.ValueString(),
 		Pooltype: state.Pooltype.ValueString(),
@@ -527,11 +596,12 @@ func (r *diskpoolResource) Update(ctx context.Context, req resource.UpdateReques
 	}
 
 	// Update existing order
-	respObject, res, err := r.client.DiskPoolApi.UpdateDiskPool(r.authCtx, state.ID.ValueString(), &reqBody)
+	respObject, res, err := r.client.DiskPoolApi.UpdateDiskPool(authCtx, state.ID.ValueString(), &reqBody)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addConsoleError(&resp.Diagnostics,
 			"Error Updating DiskPool",
-			"An unexpected error occurred when updating the BackupDR DiskPool, unexpected error: "+err.Error(),
+			"Could not update DiskPool with ID "+state.ID.ValueString()+".",
+			res, err, diskpoolConsoleFields,
 		)
 		return
 	}
@@ -595,6 +665,10 @@ func (r *diskpoolResource) Update(ctx context.Context, req resource.UpdateReques
 }
 
 func (r *diskpoolResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !checkProviderConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	// Retrieve values from state
 	var state diskPoolResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -603,18 +677,31 @@ func (r *diskpoolResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, authCtx, cancel := withTimeout(ctx, r.authCtx, deleteTimeout)
+	defer cancel()
+
 	// Delete existing Diskpool
-	_, err := r.client.DiskPoolApi.DeleteDiskPool(r.authCtx, state.ID.ValueString())
+	res, err := r.client.DiskPoolApi.DeleteDiskPool(authCtx, state.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addConsoleError(&resp.Diagnostics,
 			"Error Deleting DiskPool",
-			"Could not delete diskpool, unexpected error: "+err.Error(),
+			"Could not delete DiskPool with ID "+state.ID.ValueString()+".",
+			res, err, nil,
 		)
 		return
 	}
 }
 
 func (r *diskpoolResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if !checkProviderConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	// Retrieve import ID and save to id attribute
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
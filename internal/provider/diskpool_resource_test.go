@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccDiskpoolResourceConfig(console *fakeConsole, name string) string {
+	return testAccProviderConfig(console) + fmt.Sprintf(`
+resource "backupdr_diskpool" "test" {
+  name                = %q
+  pooltype            = "vault"
+  appliance_clusterid = "144292692833"
+  properties = [
+    {
+      key   = "bucket"
+      value = "test-bucket"
+    },
+  ]
+}
+`, name)
+}
+
+func TestAccDiskpoolResource(t *testing.T) {
+	console := newFakeConsole(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             console.CheckDestroyed("diskpool"),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccDiskpoolResourceConfig(console, "onvault-pool"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("backupdr_diskpool.test", "name", "onvault-pool"),
+					resource.TestCheckResourceAttr("backupdr_diskpool.test", "appliance_clusterid", "144292692833"),
+					resource.TestCheckResourceAttrSet("backupdr_diskpool.test", "id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "backupdr_diskpool.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccDiskpoolResourceConfig(console, "onvault-pool-renamed"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("backupdr_diskpool.test", "name", "onvault-pool-renamed"),
+					console.CheckField("diskpool", "backupdr_diskpool.test", "name", "onvault-pool-renamed"),
+				),
+			},
+			// Drift testing
+			{
+				PreConfig: func() {
+					id := console.Find("diskpool", "name", "onvault-pool-renamed")
+					console.Update("diskpool", id, map[string]any{"name": "renamed-in-console"})
+				},
+				Config:             testAccDiskpoolResourceConfig(console, "onvault-pool-renamed"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Removed from state when deleted in the console
+			{
+				PreConfig: func() {
+					console.Delete("diskpool", console.Find("diskpool", "name", "renamed-in-console"))
+				},
+				Config:             testAccDiskpoolResourceConfig(console, "onvault-pool-renamed"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
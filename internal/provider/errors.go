@@ -0,0 +1,179 @@
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	backupdr "github.com/umeshkumhar/backupdr-client"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// requestIDHeader is the response header carrying the ID the console
+// assigned to a request, which support needs to trace it.
+const requestIDHeader = "X-Request-Id"
+
+// notFoundErrorCodes are the console error codes reporting that the
+// requested object does not exist. The console does not always send them
+// with a 404 status.
+var notFoundErrorCodes = map[int32]bool{
+	10006: true,
+}
+
+// consoleErrorPayload is the body the console sends with a failed request.
+type consoleErrorPayload struct {
+	ErrCode    int32  `json:"err_code"`
+	ErrMessage string `json:"err_message"`
+	// Field is the request field the error refers to, when the console
+	// reports one.
+	Field string `json:"field,omitempty"`
+}
+
+// consoleError returns the error payload the console sent with a failed
+// request, if any.
+func consoleError(err error) (consoleErrorPayload, bool) {
+	// The client only decodes the payload for some status codes, and drops
+	// the fields it does not know about, so the body is decoded here.
+	var swaggerErr backupdr.GenericSwaggerError
+	if !errors.As(err, &swaggerErr) {
+		return consoleErrorPayload{}, false
+	}
+
+	var payload consoleErrorPayload
+	if json.Unmarshal(swaggerErr.Body(), &payload) != nil || (payload.ErrCode == 0 && payload.ErrMessage == "") {
+		return consoleErrorPayload{}, false
+	}
+	return payload, true
+}
+
+// requestError is a failed console request, with what the console said
+// about it.
+type requestError struct {
+	Method     string
+	Endpoint   string
+	StatusCode int
+	RequestID  string
+	// CorrelationID is the ID the provider sent with the request, which
+	// finds it in the backupdr_http logs and dumps.
+	CorrelationID string
+	// Console is the console's error payload, if it sent one.
+	Console *consoleErrorPayload
+
+	err error
+}
+
+// newRequestError describes the failed request that returned res and err.
+// res is nil when no response was received. Errors that already describe
+// their request are returned unchanged.
+func newRequestError(res *http.Response, err error) *requestError {
+	var reqErr *requestError
+	if errors.As(err, &reqErr) {
+		return reqErr
+	}
+
+	reqErr = &requestError{err: err}
+	if res != nil {
+		reqErr.StatusCode = res.StatusCode
+		reqErr.RequestID = res.Header.Get(requestIDHeader)
+		if res.Request != nil {
+			reqErr.Method = res.Request.Method
+			reqErr.Endpoint = res.Request.URL.Path
+			reqErr.CorrelationID = res.Request.Header.Get(correlationIDHeader)
+		}
+	} else {
+		// The transport failed, so the request is only known from the
+		// error.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			reqErr.Method = strings.ToUpper(urlErr.Op)
+			if u, parseErr := url.Parse(urlErr.URL); parseErr == nil {
+				reqErr.Endpoint = u.Path
+			}
+		}
+	}
+	if payload, ok := consoleError(err); ok {
+		reqErr.Console = &payload
+	}
+	return reqErr
+}
+
+// Error implements error.
+func (e *requestError) Error() string {
+	var b strings.Builder
+	if e.Method != "" {
+		fmt.Fprintf(&b, "%s %s: ", e.Method, e.Endpoint)
+	}
+	if e.Console != nil {
+		fmt.Fprintf(&b, "console error %d: %s", e.Console.ErrCode, e.Console.ErrMessage)
+	} else {
+		b.WriteString(e.err.Error())
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " (request ID %s)", e.RequestID)
+	}
+	return b.String()
+}
+
+// Unwrap returns the error returned by the client.
+func (e *requestError) Unwrap() error {
+	return e.err
+}
+
+// detail formats the error for a diagnostic, one fact per line.
+func (e *requestError) detail() string {
+	var lines []string
+	if e.Console != nil {
+		lines = append(lines, fmt.Sprintf("Console error %d: %s", e.Console.ErrCode, e.Console.ErrMessage))
+		if e.Console.Field != "" {
+			lines = append(lines, "Field: "+e.Console.Field)
+		}
+	} else {
+		lines = append(lines, "Error: "+e.err.Error())
+	}
+	if e.Method != "" {
+		lines = append(lines, fmt.Sprintf("Request: %s %s", e.Method, e.Endpoint))
+	}
+	if e.RequestID != "" {
+		lines = append(lines, "Request ID: "+e.RequestID)
+	}
+	if e.CorrelationID != "" {
+		lines = append(lines, "Correlation ID: "+e.CorrelationID)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// addConsoleError adds an error diagnostic for the failed request that
+// returned res and err. detail says what was attempted; the console's error
+// code and message and the request are appended to it. An error the console
+// reports against a request field is attached to the attribute fields maps
+// that field to.
+func addConsoleError(diags *diag.Diagnostics, summary, detail string, res *http.Response, err error, fields map[string]path.Path) {
+	reqErr := newRequestError(res, err)
+	detail += "\n\n" + reqErr.detail()
+
+	if reqErr.Console != nil && reqErr.Console.Field != "" {
+		if attribute, ok := fields[reqErr.Console.Field]; ok {
+			diags.AddAttributeError(attribute, summary, detail)
+			return
+		}
+	}
+	diags.AddError(summary, detail)
+}
+
+// isNotFound reports whether a failed request means the object no longer
+// exists, so the resource should be removed from state.
+func isNotFound(res *http.Response, err error) bool {
+	if err == nil {
+		return false
+	}
+	reqErr := newRequestError(res, err)
+	if reqErr.StatusCode == http.StatusNotFound {
+		return true
+	}
+	return reqErr.Console != nil && notFoundErrorCodes[reqErr.Console.ErrCode]
+}
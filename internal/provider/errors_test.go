@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	backupdr "github.com/umeshkumhar/backupdr-client"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func TestIsNotFound(t *testing.T) {
+	tests := map[string]struct {
+		status int
+		body   string
+		want   bool
+	}{
+		"found": {
+			status: http.StatusOK,
+			body:   `{"id":"1","name":"pool"}`,
+		},
+		"404": {
+			status: http.StatusNotFound,
+			body:   `{"err_code":10006,"err_message":"diskpool 1 not found"}`,
+			want:   true,
+		},
+		"404 without payload": {
+			status: http.StatusNotFound,
+			want:   true,
+		},
+		"not found error code": {
+			status: http.StatusBadRequest,
+			body:   `{"err_code":10006,"err_message":"diskpool 1 not found"}`,
+			want:   true,
+		},
+		"other error code": {
+			status: http.StatusBadRequest,
+			body:   `{"err_code":10001,"err_message":"bad request"}`,
+		},
+		"server error": {
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			}))
+			t.Cleanup(srv.Close)
+
+			cfg := backupdr.NewConfiguration()
+			cfg.Host = srv.URL
+			client := backupdr.NewAPIClient(cfg)
+
+			_, res, err := client.DiskPoolApi.GetDiskPool(context.Background(), "1")
+			if got := isNotFound(res, err); got != tt.want {
+				t.Errorf("isNotFound() = %v, want %v (err: %v)", got, tt.want, err)
+			}
+		})
+	}
+}
+
+func TestAddConsoleError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(requestIDHeader, "req-42")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"err_code":10016,"err_message":"name is already used","field":"name"}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := backupdr.NewConfiguration()
+	cfg.Host = srv.URL
+	client := backupdr.NewAPIClient(cfg)
+
+	_, res, err := client.DiskPoolApi.CreateDiskPool(context.Background(), nil)
+	if err == nil {
+		t.Fatal("expected the request to fail")
+	}
+
+	t.Run("mapped field", func(t *testing.T) {
+		var diags diag.Diagnostics
+		addConsoleError(&diags, "Error creating Diskpool", "Could not create Diskpool.", res, err, diskpoolConsoleFields)
+
+		if len(diags) != 1 {
+			t.Fatalf("got %d diagnostics, want 1", len(diags))
+		}
+		withPath, ok := diags[0].(diag.DiagnosticWithPath)
+		if !ok {
+			t.Fatalf("got diagnostic without attribute path: %v", diags[0])
+		}
+		if !withPath.Path().Equal(path.Root("name")) {
+			t.Errorf("got path %s, want name", withPath.Path())
+		}
+
+		want := "Could not create Diskpool.\n\n" +
+			"Console error 10016: name is already used\n" +
+			"Field: name\n" +
+			"Request: POST /actifio/diskpool\n" +
+			"Request ID: req-42"
+		if got := diags[0].Detail(); got != want {
+			t.Errorf("got detail\n%s\nwant\n%s", got, want)
+		}
+	})
+
+	t.Run("unmapped field", func(t *testing.T) {
+		var diags diag.Diagnostics
+		addConsoleError(&diags, "Error creating Diskpool", "Could not create Diskpool.", res, err, nil)
+
+		if _, ok := diags[0].(diag.DiagnosticWithPath); ok {
+			t.Errorf("got attribute diagnostic for an unmapped field")
+		}
+	})
+
+	t.Run("wrapped error", func(t *testing.T) {
+		wrapped := newRequestError(res, err)
+		if got, want := wrapped.Error(), "POST /actifio/diskpool: console error 10016: name is already used (request ID req-42)"; got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+
+		// Helpers return the wrapped error without the response.
+		var diags diag.Diagnostics
+		addConsoleError(&diags, "Error creating Diskpool", "Could not create Diskpool.", nil, wrapped, diskpoolConsoleFields)
+		if _, ok := diags[0].(diag.DiagnosticWithPath); !ok {
+			t.Errorf("got diagnostic without attribute path: %v", diags[0])
+		}
+	})
+}
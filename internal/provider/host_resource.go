@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -21,6 +22,17 @@ var (
 	_ resource.ResourceWithImportState = &hostResource{}
 )
 
+// hostConsoleFields maps the fields of vCenter host requests to the attributes
+// they are set from, so console errors are reported against them.
+var hostConsoleFields = map[string]path.Path{
+	"hostname":        path.Root("hostname"),
+	"hosttype":        path.Root("hosttype"),
+	"friendlypath":    path.Root("friendlypath"),
+	"ipaddress":       path.Root("ipaddress"),
+	"hypervisoragent": path.Root("hypervisoragent"),
+	"sources":         path.Root("appliance_clusterid"),
+}
+
 // NewHostResource to create vCenter Host
 func NewHostResource() resource.Resource {
 	return &hostResource{}
@@ -30,6 +42,7 @@ func NewHostResource() resource.Resource {
 type hostResource struct {
 	client  *backupdr.APIClient
 	authCtx context.Context
+	secrets *secrets
 }
 
 // Metadata returns the resource type name.
@@ -38,7 +51,7 @@ func (r *hostResource) Metadata(_ context.Context, req resource.MetadataRequest,
 }
 
 // Schema defines the schema for the resource.
-func (r *hostResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *hostResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Manages an vCenter Host.",
 		Attributes: map[string]schema.Attribute{
@@ -269,6 +282,9 @@ func (r *hostResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 			// 	},
 			// },
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": resourceTimeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -280,10 +296,15 @@ func (r *hostResource) Configure(_ context.Context, req resource.ConfigureReques
 
 	r.client = req.ProviderData.(*backupdrProvider).client
 	r.authCtx = req.ProviderData.(*backupdrProvider).authCtx
+	r.secrets = req.ProviderData.(*backupdrProvider).secrets
 }
 
 // Create a new resource.
 func (r *hostResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !checkProviderConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	// Retrieve values from plan
 	var plan vcenterHostRest
 	diags := req.Plan.Get(ctx, &plan)
@@ -292,6 +313,14 @@ func (r *hostResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, authCtx, cancel := withTimeout(ctx, r.authCtx, createTimeout)
+	defer cancel()
+
 	reqVcenterHost := backupdr.HostRest{
 		Hostname:     plan.Hostname.ValueString(),
 		Hosttype:     plan.Hosttype.ValueString(),
@@ -300,6 +329,7 @@ func (r *hostResource) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 
 	if plan.Hypervisoragent != nil {
+		r.secrets.Add(plan.Hypervisoragent.Password.ValueString())
 		reqVcenterHost.Hypervisoragent = &backupdr.AgentRest{
 			Username: plan.Hypervisoragent.Username.ValueString(),
 			Password: plan.Hypervisoragent.Password.ValueString(),
@@ -316,11 +346,12 @@ func (r *hostResource) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 
 	// Create new vCenter Host
-	respObject, _, err := r.client.HostApi.CreateHost(r.authCtx, &reqBody)
+	respObject, res, err := r.client.HostApi.CreateHost(authCtx, &reqBody)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addConsoleError(&resp.Diagnostics,
 			"Error creating vCenter Host",
-			"Could not create vCenter Host, unexpected error: "+err.Error(),
+			"Could not create vCenter Host.",
+			res, err, hostConsoleFields,
 		)
 		return
 	}
@@ -358,9 +389,11 @@ func (r *hostResource) Create(ctx context.Context, req resource.CreateRequest, r
 	plan.Uniquename = types.StringValue(respObject.Uniquename)
 	plan.Zone = types.StringValue(respObject.Zone)
 
-	plan.Hypervisoragent.Haspassword = types.BoolValue(respObject.Hypervisoragent.Haspassword)
-	plan.Hypervisoragent.Agenttype = types.StringValue(respObject.Hypervisoragent.Agenttype)
-	plan.Hypervisoragent.Hasalternatekey = types.BoolValue(respObject.Hypervisoragent.Hasalternatekey)
+	if plan.Hypervisoragent != nil && respObject.Hypervisoragent != nil {
+		plan.Hypervisoragent.Haspassword = types.BoolValue(respObject.Hypervisoragent.Haspassword)
+		plan.Hypervisoragent.Agenttype = types.StringValue(respObject.Hypervisoragent.Agenttype)
+		plan.Hypervisoragent.Hasalternatekey = types.BoolValue(respObject.Hypervisoragent.Hasalternatekey)
+	}
 
 	// plan.Agents = []types.Object{{
 	// 	Agenttype:       types.StringValue(respObject.Agents[0].Agenttype),
@@ -389,6 +422,10 @@ func (r *hostResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 // Read resource information.
 func (r *hostResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if unknownProviderConfig(ctx, r.client) {
+		return
+	}
+
 	// Get current state
 	var state vcenterHostRest
 	diags := req.State.Get(ctx, &state)
@@ -397,12 +434,26 @@ func (r *hostResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, authCtx, cancel := withTimeout(ctx, r.authCtx, readTimeout)
+	defer cancel()
+
 	// Get refreshed values
-	respObject, _, err := r.client.HostApi.GetHost(r.authCtx, state.ID.ValueString(), nil)
+	respObject, res, err := r.client.HostApi.GetHost(authCtx, state.ID.ValueString(), nil)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		if isNotFound(res, err) {
+			tflog.Warn(ctx, "vCenter Host no longer exists, removing it from state", map[string]any{"id": state.ID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addConsoleError(&resp.Diagnostics,
 			"Error Reading vCenter Host",
-			"Could not read vCenter Host with ID "+state.ID.ValueString()+": "+err.Error(),
+			"Could not read vCenter Host with ID "+state.ID.ValueString()+".",
+			res, err, nil,
 		)
 		return
 	}
@@ -441,9 +492,23 @@ func (r *hostResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	state.Uniquename = types.StringValue(respObject.Uniquename)
 	state.Zone = types.StringValue(respObject.Zone)
 
-	state.Hypervisoragent.Haspassword = types.BoolValue(respObject.Hypervisoragent.Haspassword)
-	state.Hypervisoragent.Agenttype = types.StringValue(respObject.Hypervisoragent.Agenttype)
-	state.Hypervisoragent.Hasalternatekey = types.BoolValue(respObject.Hypervisoragent.Hasalternatekey)
+	// An imported host has no agent or appliance in state yet, so take them
+	// over from the console. The password is never returned.
+	if state.Hypervisoragent == nil && respObject.Hypervisoragent != nil {
+		state.Hypervisoragent = &agentRest{
+			Username: types.StringValue(respObject.Hypervisoragent.Username),
+			Password: types.StringNull(),
+		}
+	}
+	if state.ApplianceClusterID.IsNull() && len(respObject.Sources) > 0 {
+		state.ApplianceClusterID = types.StringValue(respObject.Sources[0].Clusterid)
+	}
+
+	if state.Hypervisoragent != nil && respObject.Hypervisoragent != nil {
+		state.Hypervisoragent.Haspassword = types.BoolValue(respObject.Hypervisoragent.Haspassword)
+		state.Hypervisoragent.Agenttype = types.StringValue(respObject.Hypervisoragent.Agenttype)
+		state.Hypervisoragent.Hasalternatekey = types.BoolValue(respObject.Hypervisoragent.Hasalternatekey)
+	}
 
 	// state.Agents = []AgentRest{{
 	// 	Agenttype:       types.StringValue(respObject.Agents[0].Agenttype),
@@ -471,6 +536,10 @@ func (r *hostResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *hostResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !checkProviderConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	// Retrieve values from plan
 	var plan vcenterHostRest
 	diags := req.Plan.Get(ctx, &plan)
@@ -479,6 +548,14 @@ func (r *hostResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, authCtx, cancel := withTimeout(ctx, r.authCtx, updateTimeout)
+	defer cancel()
+
 	reqVcenterHost := backupdr.HostRest{
 		Hostname:     plan.Hostname.ValueString(),
 		Hosttype:     plan.Hosttype.ValueString(),
@@ -487,6 +564,7 @@ func (r *hostResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	}
 
 	if plan.Hypervisoragent != nil {
+		r.secrets.Add(plan.Hypervisoragent.Password.ValueString())
 		reqVcenterHost.Hypervisoragent = &backupdr.AgentRest{
 			Username: plan.Hypervisoragent.Username.ValueString(),
 			Password: plan.Hypervisoragent.Password.ValueString(),
@@ -503,11 +581,12 @@ func (r *hostResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	}
 
 	// Update vCenter Host
-	respObject, res, err := r.client.HostApi.UpdateHost(r.authCtx, plan.ID.ValueString(), &reqBody)
+	respObject, res, err := r.client.HostApi.UpdateHost(authCtx, plan.ID.ValueString(), &reqBody)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addConsoleError(&resp.Diagnostics,
 			"Error updating vCenter Host",
-			"Could not updating vCenter Host, unexpected error: "+err.Error(),
+			"Could not update vCenter Host with ID "+plan.ID.ValueString()+".",
+			res, err, hostConsoleFields,
 		)
 		return
 	}
@@ -554,9 +633,11 @@ func (r *hostResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	plan.Uniquename = types.StringValue(respObject.Uniquename)
 	plan.Zone = types.StringValue(respObject.Zone)
 
-	plan.Hypervisoragent.Haspassword = types.BoolValue(respObject.Hypervisoragent.Haspassword)
-	plan.Hypervisoragent.Agenttype = types.StringValue(respObject.Hypervisoragent.Agenttype)
-	plan.Hypervisoragent.Hasalternatekey = types.BoolValue(respObject.Hypervisoragent.Hasalternatekey)
+	if plan.Hypervisoragent != nil && respObject.Hypervisoragent != nil {
+		plan.Hypervisoragent.Haspassword = types.BoolValue(respObject.Hypervisoragent.Haspassword)
+		plan.Hypervisoragent.Agenttype = types.StringValue(respObject.Hypervisoragent.Agenttype)
+		plan.Hypervisoragent.Hasalternatekey = types.BoolValue(respObject.Hypervisoragent.Hasalternatekey)
+	}
 
 	// plan.Agents = []AgentRest{{
 	// 	Agenttype:       types.StringValue(respObject.Agents[0].Agenttype),
@@ -583,6 +664,10 @@ func (r *hostResource) Update(ctx context.Context, req resource.UpdateRequest, r
 }
 
 func (r *hostResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !checkProviderConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	// Retrieve values from state
 	var state vcenterHostRest
 	diags := req.State.Get(ctx, &state)
@@ -591,18 +676,31 @@ func (r *hostResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, authCtx, cancel := withTimeout(ctx, r.authCtx, deleteTimeout)
+	defer cancel()
+
 	// Delete existing vCenter Host
-	_, err := r.client.HostApi.DeleteHost(r.authCtx, state.ID.ValueString())
+	res, err := r.client.HostApi.DeleteHost(authCtx, state.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addConsoleError(&resp.Diagnostics,
 			"Error Deleting vCenter Host",
-			"Could not delete vCenter Host, unexpected error: "+err.Error(),
+			"Could not delete vCenter Host with ID "+state.ID.ValueString()+".",
+			res, err, nil,
 		)
 		return
 	}
 }
 
 func (r *hostResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if !checkProviderConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	// Retrieve import ID and save to id attribute
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
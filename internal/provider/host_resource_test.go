@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccHostResourceConfig(console *fakeConsole, ipaddress string) string {
+	return testAccProviderConfig(console) + fmt.Sprintf(`
+resource "backupdr_host" "test" {
+  hostname            = "vcenter-1"
+  friendlypath        = "vcenter-1"
+  hosttype            = "vcenter"
+  ipaddress           = %q
+  appliance_clusterid = "144292692833"
+  hypervisoragent = {
+    username = "administrator@vsphere.local"
+    password = "secret"
+  }
+}
+`, ipaddress)
+}
+
+func TestAccHostResource(t *testing.T) {
+	console := newFakeConsole(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             console.CheckDestroyed("host"),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccHostResourceConfig(console, "10.0.0.10"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("backupdr_host.test", "hostname", "vcenter-1"),
+					resource.TestCheckResourceAttr("backupdr_host.test", "ipaddress", "10.0.0.10"),
+					resource.TestCheckResourceAttrSet("backupdr_host.test", "id"),
+					console.CheckField("host", "backupdr_host.test", "hosttype", "vcenter"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "backupdr_host.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"hypervisoragent.password"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccHostResourceConfig(console, "10.0.0.11"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("backupdr_host.test", "ipaddress", "10.0.0.11"),
+					console.CheckField("host", "backupdr_host.test", "ipaddress", "10.0.0.11"),
+				),
+			},
+			// Drift testing
+			{
+				PreConfig: func() {
+					id := console.Find("host", "hostname", "vcenter-1")
+					console.Update("host", id, map[string]any{"ipaddress": "10.0.0.99"})
+				},
+				Config:             testAccHostResourceConfig(console, "10.0.0.11"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Removed from state when deleted in the console
+			{
+				PreConfig: func() {
+					console.Delete("host", console.Find("host", "hostname", "vcenter-1"))
+				},
+				Config:             testAccHostResourceConfig(console, "10.0.0.11"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
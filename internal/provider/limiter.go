@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/time/rate"
+)
+
+// limitTransport gates every management console request of a provider
+// instance, so that Terraform's parallelism does not flood the console. It
+// sits below the retries, so every attempt and every login waits its turn.
+// The time a request waited is logged to httpSubsystem.
+type limitTransport struct {
+	next http.RoundTripper
+	// slots holds one token per request in flight. It is nil when the
+	// number of concurrent requests is not limited.
+	slots chan struct{}
+	// limiter is nil when the request rate is not limited.
+	limiter *rate.Limiter
+	// logCtx carries the httpSubsystem logger.
+	logCtx context.Context
+}
+
+// newLimitTransport returns a transport allowing at most maxConcurrent
+// requests in flight and requestsPerSecond requests per second. Zero disables
+// either limit.
+func newLimitTransport(next http.RoundTripper, maxConcurrent int, requestsPerSecond float64, logCtx context.Context) *limitTransport {
+	t := &limitTransport{next: next, logCtx: logCtx}
+	if maxConcurrent > 0 {
+		t.slots = make(chan struct{}, maxConcurrent)
+	}
+	if requestsPerSecond > 0 {
+		t.limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), int(math.Ceil(requestsPerSecond)))
+	}
+	return t
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.slots == nil && t.limiter == nil {
+		return t.next.RoundTrip(req)
+	}
+
+	ctx := req.Context()
+	start := time.Now()
+
+	release := func() {}
+	if t.slots != nil {
+		select {
+		case t.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		var once sync.Once
+		release = func() { once.Do(func() { <-t.slots }) }
+	}
+
+	if t.limiter != nil {
+		if err := t.limiter.Wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	if wait := time.Since(start); wait >= time.Millisecond {
+		tflog.SubsystemDebug(t.logCtx, httpSubsystem, "Management console request waited for the request limits", map[string]any{
+			"method":         req.Method,
+			"url":            req.URL.String(),
+			"correlation_id": req.Header.Get(correlationIDHeader),
+			"wait_ms":        wait.Milliseconds(),
+		})
+	}
+
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	// The request is in flight until its body has been read.
+	res.Body = &releaseOnClose{ReadCloser: res.Body, release: release}
+	return res, nil
+}
+
+// releaseOnClose calls release once its body is closed.
+type releaseOnClose struct {
+	io.ReadCloser
+	release func()
+}
+
+// Close implements io.Closer.
+func (b *releaseOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
@@ -0,0 +1,320 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// maskedValue replaces secrets in logs.
+const maskedValue = "***"
+
+// secretKeys are the log field keys, request headers and JSON body keys
+// whose values are always masked.
+var secretKeys = []string{
+	"access_token",
+	"authorization",
+	"credentials",
+	"password",
+	"session_id",
+	sessionHeader,
+}
+
+// secretBodyPattern matches string values of secretKeys in JSON bodies.
+var secretBodyPattern = regexp.MustCompile(`("(?i:` + strings.Join(secretKeys, "|") + `)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// secrets collects the secret values a provider instance has seen, such as
+// the access token, the session ID and passwords sent to the console, so they
+// are masked wherever they show up in logs. The zero value is ready to use
+// and a nil *secrets masks only secretKeys.
+type secrets struct {
+	mu     sync.RWMutex
+	values map[string]struct{}
+}
+
+// Add registers secret values. Empty values are ignored.
+func (s *secrets) Add(values ...string) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, value := range values {
+		if value == "" {
+			continue
+		}
+		if s.values == nil {
+			s.values = map[string]struct{}{}
+		}
+		s.values[value] = struct{}{}
+	}
+}
+
+// Values returns the registered values, longest first so that a secret
+// containing another one is masked as a whole.
+func (s *secrets) Values() []string {
+	if s == nil {
+		return nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	values := make([]string, 0, len(s.values))
+	for value := range s.values {
+		values = append(values, value)
+	}
+	sort.Slice(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+	return values
+}
+
+// Mask returns ctx with log masks for secretKeys and every registered value.
+// Values registered later are only masked in contexts masked after that.
+func (s *secrets) Mask(ctx context.Context) context.Context {
+	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, secretKeys...)
+	if values := s.Values(); len(values) > 0 {
+		ctx = tflog.MaskLogStrings(ctx, values...)
+	}
+	return ctx
+}
+
+// MaskSubsystem is Mask for the logger of subsystem.
+func (s *secrets) MaskSubsystem(ctx context.Context, subsystem string) context.Context {
+	ctx = tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, subsystem, secretKeys...)
+	if values := s.Values(); len(values) > 0 {
+		ctx = tflog.SubsystemMaskLogStrings(ctx, subsystem, values...)
+	}
+	return ctx
+}
+
+// Redact masks the values of secretKeys in a JSON document and every
+// registered value in text.
+func (s *secrets) Redact(text string) string {
+	text = secretBodyPattern.ReplaceAllString(text, `$1"`+maskedValue+`"`)
+	for _, value := range s.Values() {
+		text = strings.ReplaceAll(text, value, maskedValue)
+	}
+	return text
+}
+
+// redactHeaders returns the headers as log fields, masking secretKeys.
+func redactHeaders(header http.Header) map[string]string {
+	fields := make(map[string]string, len(header))
+	for key, values := range header {
+		value := strings.Join(values, ", ")
+		for _, secret := range secretKeys {
+			if strings.EqualFold(key, secret) {
+				value = maskedValue
+				break
+			}
+		}
+		fields[key] = value
+	}
+	return fields
+}
+
+// httpSubsystem is the tflog subsystem management console calls are logged
+// to. Its level can be set apart from the provider's with
+// TF_LOG_PROVIDER_BACKUPDR_HTTP.
+const httpSubsystem = "backupdr_http"
+
+// correlationIDHeader carries the ID that ties the log entries, dumps and
+// diagnostics of one console call together.
+const correlationIDHeader = "X-Correlation-Id"
+
+// newHTTPLogContext returns ctx with the httpSubsystem logger.
+func newHTTPLogContext(ctx context.Context) context.Context {
+	return tflog.NewSubsystem(ctx, httpSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_BACKUPDR", "HTTP"))
+}
+
+// newCorrelationID returns a random ID for a console call.
+func newCorrelationID() string {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// correlationTransport gives every console call a correlation ID. It sits
+// above the retries, so every attempt of a call shares the ID.
+type correlationTransport struct {
+	next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *correlationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get(correlationIDHeader) != "" {
+		return t.next.RoundTrip(req)
+	}
+
+	out := req.Clone(req.Context())
+	out.Header.Set(correlationIDHeader, newCorrelationID())
+	return t.next.RoundTrip(out)
+}
+
+// loggingTransport logs the method, URL, status and latency of every
+// management console request to httpSubsystem at DEBUG level. When
+// configured, it also logs headers and bodies at TRACE level and writes every
+// request and response pair to a file in dumpDir. Secrets are masked
+// throughout.
+type loggingTransport struct {
+	next    http.RoundTripper
+	secrets *secrets
+	// logCtx carries the httpSubsystem logger. The client is called with
+	// contexts that only carry credentials, so requests cannot be logged
+	// through their own context.
+	logCtx    context.Context
+	logBodies bool
+	dumpDir   string
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := t.secrets.MaskSubsystem(t.logCtx, httpSubsystem)
+	fields := map[string]any{
+		"method":         req.Method,
+		"url":            req.URL.String(),
+		"correlation_id": req.Header.Get(correlationIDHeader),
+	}
+
+	var reqBody string
+	if t.logBodies || t.dumpDir != "" {
+		var err error
+		if reqBody, req, err = peekRequestBody(req); err != nil {
+			return nil, err
+		}
+	}
+	if t.logBodies {
+		tflog.SubsystemTrace(ctx, httpSubsystem, "Sending management console request", withFields(fields, map[string]any{
+			"headers": redactHeaders(req.Header),
+			"body":    t.secrets.Redact(reqBody),
+		}))
+	}
+
+	start := time.Now()
+	res, err := t.next.RoundTrip(req)
+	fields["latency_ms"] = time.Since(start).Milliseconds()
+	if err != nil {
+		tflog.SubsystemDebug(ctx, httpSubsystem, "Management console request failed", withFields(fields, map[string]any{
+			"error": t.secrets.Redact(err.Error()),
+		}))
+		t.dump(ctx, start, req, reqBody, nil, nil)
+		return nil, err
+	}
+	fields["status"] = res.StatusCode
+	tflog.SubsystemDebug(ctx, httpSubsystem, "Management console request completed", fields)
+
+	if !t.logBodies && t.dumpDir == "" {
+		return res, nil
+	}
+
+	resBody, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+	if t.logBodies {
+		tflog.SubsystemTrace(ctx, httpSubsystem, "Received management console response", withFields(fields, map[string]any{
+			"headers": redactHeaders(res.Header),
+			"body":    t.secrets.Redact(string(resBody)),
+		}))
+	}
+	t.dump(ctx, start, req, reqBody, res, resBody)
+	return res, nil
+}
+
+// dump writes a redacted request and response pair to a new file in
+// t.dumpDir. res is nil when no response was received. Failing to write the
+// dump is logged and does not fail the request.
+func (t *loggingTransport) dump(ctx context.Context, start time.Time, req *http.Request, reqBody string, res *http.Response, resBody []byte) {
+	if t.dumpDir == "" {
+		return
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s\n", req.Method, req.URL.String())
+	writeRedactedHeaders(&b, req.Header)
+	fmt.Fprintf(&b, "\n%s\n", t.secrets.Redact(reqBody))
+	if res != nil {
+		fmt.Fprintf(&b, "\n%s %s\n", res.Proto, res.Status)
+		writeRedactedHeaders(&b, res.Header)
+		fmt.Fprintf(&b, "\n%s\n", t.secrets.Redact(string(resBody)))
+	}
+
+	name := fmt.Sprintf("%s-%s.http", start.UTC().Format("20060102T150405.000000000"), req.Header.Get(correlationIDHeader))
+	if err := os.WriteFile(filepath.Join(t.dumpDir, name), []byte(b.String()), 0o600); err != nil {
+		tflog.SubsystemWarn(ctx, httpSubsystem, "Unable to write management console request dump", map[string]any{
+			"error": err.Error(),
+		})
+	}
+}
+
+// writeRedactedHeaders writes headers sorted by name, masking secretKeys.
+func writeRedactedHeaders(b *strings.Builder, header http.Header) {
+	fields := redactHeaders(header)
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(b, "%s: %s\n", key, fields[key])
+	}
+}
+
+// withFields returns a copy of fields with extra added.
+func withFields(fields, extra map[string]any) map[string]any {
+	out := make(map[string]any, len(fields)+len(extra))
+	for k, v := range fields {
+		out[k] = v
+	}
+	for k, v := range extra {
+		out[k] = v
+	}
+	return out
+}
+
+// peekRequestBody returns the body of req and a request that can still be
+// sent. The body is read from a copy when the request can replay it.
+func peekRequestBody(req *http.Request) (string, *http.Request, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return "", req, nil
+	}
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return "", nil, err
+		}
+		defer body.Close()
+		data, err := io.ReadAll(body)
+		return string(data), req, err
+	}
+
+	data, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return "", nil, err
+	}
+	out := req.Clone(req.Context())
+	out.Body = io.NopCloser(bytes.NewReader(data))
+	return string(data), out, nil
+}
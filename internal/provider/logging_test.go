@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestSecretsRedact(t *testing.T) {
+	s := &secrets{}
+	s.Add("ya29.token", "")
+
+	got := s.Redact(`{"hostname":"vcenter-1","hypervisoragent":{"username":"admin","Password":"p\"ss"},"note":"Bearer ya29.token"}`)
+	want := `{"hostname":"vcenter-1","hypervisoragent":{"username":"admin","Password":"***"},"note":"Bearer ***"}`
+	if got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	var none *secrets
+	if got := none.Redact(`{"session_id":"abc"}`); got != `{"session_id":"***"}` {
+		t.Errorf("got %s from nil secrets", got)
+	}
+}
+
+func TestSecretsMask(t *testing.T) {
+	var out bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &out)
+
+	s := &secrets{}
+	s.Add("ya29.token")
+	ctx = s.Mask(ctx)
+
+	tflog.Info(ctx, "using token ya29.token", map[string]any{
+		"BACKUPDR_ENDPOINT": "https://console.example.com",
+		"password":          "hunter2",
+	})
+
+	if strings.Contains(out.String(), "ya29.token") || strings.Contains(out.String(), "hunter2") {
+		t.Errorf("secret logged: %s", out.String())
+	}
+	if !strings.Contains(out.String(), "https://console.example.com") {
+		t.Errorf("endpoint not logged: %s", out.String())
+	}
+}
+
+func TestLoggingTransport(t *testing.T) {
+	var received string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = string(body)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"session_id":"session-1"}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	var out bytes.Buffer
+	s := &secrets{}
+	s.Add("ya29.token")
+	client := &http.Client{Transport: &loggingTransport{
+		next:      http.DefaultTransport,
+		secrets:   s,
+		logCtx:    newHTTPLogContext(tflogtest.RootLogger(context.Background(), &out)),
+		logBodies: true,
+	}}
+
+	req, err := http.NewRequest(http.MethodPost, srv.URL+"/actifio/host", strings.NewReader(`{"hypervisoragent":{"password":"hunter2"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer ya29.token")
+	req.Header.Set(sessionHeader, "Actifio session-0")
+
+	res, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	// The bodies are still sent and returned in full.
+	if received != `{"hypervisoragent":{"password":"hunter2"}}` {
+		t.Errorf("console received %s", received)
+	}
+	body, _ := io.ReadAll(res.Body)
+	if string(body) != `{"session_id":"session-1"}` {
+		t.Errorf("got response body %s", body)
+	}
+
+	for _, secret := range []string{"ya29.token", "hunter2", "session-0", "session-1"} {
+		if strings.Contains(out.String(), secret) {
+			t.Errorf("secret %q logged: %s", secret, out.String())
+		}
+	}
+	if !strings.Contains(out.String(), "/actifio/host") {
+		t.Errorf("request not logged: %s", out.String())
+	}
+}
+
+func TestLoggingTransport_correlationAndDump(t *testing.T) {
+	var seen []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Header.Get(correlationIDHeader))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"session_id":"session-1"}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	var out bytes.Buffer
+	dumpDir := t.TempDir()
+	client := &http.Client{Transport: &correlationTransport{next: &loggingTransport{
+		next:    http.DefaultTransport,
+		logCtx:  newHTTPLogContext(tflogtest.RootLogger(context.Background(), &out)),
+		dumpDir: dumpDir,
+	}}}
+
+	for i := 0; i < 2; i++ {
+		res, err := client.Post(srv.URL+"/actifio/session", "application/json", strings.NewReader(`{"password":"hunter2"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+	}
+
+	if len(seen) != 2 || seen[0] == "" || seen[0] == seen[1] {
+		t.Fatalf("got correlation IDs %q, want two distinct IDs", seen)
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("got %d log entries, want 2", len(entries))
+	}
+	for i, entry := range entries {
+		if entry["@module"] != "provider."+httpSubsystem || entry["correlation_id"] != seen[i] || entry["status"] != float64(http.StatusOK) {
+			t.Errorf("unexpected log entry %v", entry)
+		}
+		if _, ok := entry["latency_ms"]; !ok {
+			t.Errorf("log entry without latency: %v", entry)
+		}
+	}
+
+	files, err := os.ReadDir(dumpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("got %d dumps, want 2", len(files))
+	}
+	dump, err := os.ReadFile(filepath.Join(dumpDir, files[0].Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(files[0].Name(), seen[0]) && !strings.Contains(files[0].Name(), seen[1]) {
+		t.Errorf("dump %s is not named after a correlation ID", files[0].Name())
+	}
+	for _, want := range []string{"POST " + srv.URL + "/actifio/session", `{"password":"***"}`, "200 OK", `{"session_id":"***"}`} {
+		if !strings.Contains(string(dump), want) {
+			t.Errorf("dump does not contain %q:\n%s", want, dump)
+		}
+	}
+}
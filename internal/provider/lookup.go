@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// lookupCandidate is an object returned by a list API while resolving a
+// singular data source by name.
+type lookupCandidate struct {
+	ID   string
+	Name string
+}
+
+// checkLookupKeys reports whether exactly one of the ID and the name lookup
+// key of a singular data source is set.
+func checkLookupKeys(id, name types.String, nameAttribute string, diags *diag.Diagnostics) bool {
+	hasID := !id.IsNull() && id.ValueString() != ""
+	hasName := !name.IsNull() && name.ValueString() != ""
+
+	switch {
+	case hasID && hasName:
+		diags.AddAttributeError(
+			path.Root(nameAttribute),
+			"Conflicting Lookup Keys",
+			fmt.Sprintf("Set either id or %s, not both.", nameAttribute),
+		)
+		return false
+	case !hasID && !hasName:
+		diags.AddAttributeError(
+			path.Root("id"),
+			"Missing Lookup Key",
+			fmt.Sprintf("Set id or %s to select the object to read.", nameAttribute),
+		)
+		return false
+	}
+	return true
+}
+
+// resolveLookupID returns the ID of the single candidate, or reports that no
+// or several objects matched the description.
+func resolveLookupID(kind, description string, candidates []lookupCandidate, diags *diag.Diagnostics) (string, bool) {
+	switch len(candidates) {
+	case 0:
+		diags.AddError(
+			"No "+kind+" Found",
+			"No "+strings.ToLower(kind)+" matches "+description+".",
+		)
+		return "", false
+	case 1:
+		return candidates[0].ID, true
+	}
+
+	matches := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		matches = append(matches, fmt.Sprintf("%s (ID %s)", candidate.Name, candidate.ID))
+	}
+	diags.AddError(
+		"Multiple "+kind+"s Found",
+		fmt.Sprintf("%d objects match %s: %s. Narrow the lookup or set id instead.", len(candidates), description, strings.Join(matches, ", ")),
+	)
+	return "", false
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCheckLookupKeys(t *testing.T) {
+	cases := map[string]struct {
+		id, name types.String
+		want     bool
+	}{
+		"id only":   {types.StringValue("1"), types.StringNull(), true},
+		"name only": {types.StringNull(), types.StringValue("pool"), true},
+		"both":      {types.StringValue("1"), types.StringValue("pool"), false},
+		"neither":   {types.StringNull(), types.StringNull(), false},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			if got := checkLookupKeys(tc.id, tc.name, "name", &diags); got != tc.want {
+				t.Errorf("got %t, want %t", got, tc.want)
+			}
+			if diags.HasError() == tc.want {
+				t.Errorf("unexpected diagnostics: %v", diags)
+			}
+		})
+	}
+}
+
+func TestResolveLookupID(t *testing.T) {
+	var diags diag.Diagnostics
+	id, ok := resolveLookupID("Storage Pool", `name "pool"`, []lookupCandidate{{ID: "7", Name: "pool"}}, &diags)
+	if !ok || id != "7" || diags.HasError() {
+		t.Errorf("got %q, %t, %v for a single match", id, ok, diags)
+	}
+
+	diags = nil
+	if _, ok := resolveLookupID("Storage Pool", `name "pool"`, nil, &diags); ok || !diags.HasError() {
+		t.Error("expected an error when nothing matches")
+	}
+	if summary := diags[0].Summary(); summary != "No Storage Pool Found" {
+		t.Errorf("got summary %q", summary)
+	}
+
+	diags = nil
+	candidates := []lookupCandidate{{ID: "7", Name: "pool"}, {ID: "9", Name: "pool"}}
+	if _, ok := resolveLookupID("Storage Pool", `name "pool"`, candidates, &diags); ok || !diags.HasError() {
+		t.Fatal("expected an error for ambiguous matches")
+	}
+	if detail := diags[0].Detail(); !strings.Contains(detail, "ID 7") || !strings.Contains(detail, "ID 9") {
+		t.Errorf("ambiguous matches are not listed: %s", detail)
+	}
+}
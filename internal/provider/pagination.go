@@ -0,0 +1,179 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+
+	"github.com/antihax/optional"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// listPageSize is how many objects are requested per page when a list is
+// walked.
+var listPageSize int64 = 500
+
+// listOptions holds the limit, sort and filter of a list data source.
+type listOptions struct {
+	// Limit is the maximum number of objects to return. Zero returns
+	// every object.
+	Limit  int64
+	Sort   string
+	Filter string
+}
+
+// newListOptions reads the limit, sort and filter attributes of a list data
+// source.
+func newListOptions(limit types.Int64, sort, filter types.String, diags *diag.Diagnostics) (listOptions, bool) {
+	if !limit.IsNull() && limit.ValueInt64() < 1 {
+		diags.AddAttributeError(
+			path.Root("limit"),
+			"Invalid Limit",
+			fmt.Sprintf("limit must be at least 1, got %d.", limit.ValueInt64()),
+		)
+		return listOptions{}, false
+	}
+	return listOptions{
+		Limit:  limit.ValueInt64(),
+		Sort:   sort.ValueString(),
+		Filter: filter.ValueString(),
+	}, true
+}
+
+// listAttributes adds the limit, sort and filter attributes of a list data
+// source to attributes, describing the listed objects as kind.
+func listAttributes(kind string, attributes map[string]schema.Attribute) map[string]schema.Attribute {
+	attributes["limit"] = schema.Int64Attribute{
+		Optional:            true,
+		MarkdownDescription: "Provide the maximum number of " + kind + " to return. By default every page of the list is read and all " + kind + " are returned.",
+	}
+	attributes["sort"] = schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "Provide the field to sort the " + kind + " by, followed by `:asc` or `:desc`, for example `name:asc`. Sorting is case-sensitive.",
+	}
+	attributes["filter"] = schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "Provide a management console filter expression, for example `name:==prod`, to only return the matching " + kind + ". The operators are `:==` (equals), `:=|` (contains, case-insensitive), `:>=`, `:<=` and `:=b` (bitwise and).",
+	}
+	return attributes
+}
+
+// maxListPages bounds how many pages listAll requests, in case the console
+// keeps returning full pages.
+var maxListPages = 10000
+
+// listAll walks the pages of a list, keeping the objects for which keep
+// returns true, or every object when keep is nil, until limit objects are
+// kept. list returns the page of at most limit objects from offset, and the
+// total number of objects when the console reports it. The walk stops once
+// offset reaches that total, or at a page of another size than requested
+// when the console reports no total. It also stops at an empty page, and at a
+// page repeating the previous one, which means the console ignored the
+// offset.
+func listAll[T any](limit int64, list func(limit, offset int64) ([]T, int32, error), keep func(T) bool) ([]T, error) {
+	items := []T{}
+	var previous []T
+	for offset, pages := int64(0), 0; ; pages++ {
+		if pages == maxListPages {
+			return nil, fmt.Errorf("the list did not end after %d pages", maxListPages)
+		}
+
+		size := listPageSize
+		if keep == nil && limit > 0 && limit-int64(len(items)) < size {
+			size = limit - int64(len(items))
+		}
+
+		page, count, err := list(size, offset)
+		if err != nil {
+			return nil, err
+		}
+		if len(page) == 0 || (previous != nil && reflect.DeepEqual(page, previous)) {
+			return items, nil
+		}
+		previous = page
+		for _, item := range page {
+			if keep != nil && !keep(item) {
+				continue
+			}
+			items = append(items, item)
+			if limit > 0 && int64(len(items)) == limit {
+				return items, nil
+			}
+		}
+
+		// The console may return shorter pages than requested, so the
+		// length of a page only ends the list when there is no count. A
+		// page longer than requested means the console ignored the paging
+		// and returned everything.
+		offset += int64(len(page))
+		if count > 0 {
+			if offset >= int64(count) {
+				return items, nil
+			}
+		} else if int64(len(page)) != size {
+			return items, nil
+		}
+	}
+}
+
+// queryParamsKey is the context key of withQueryParams.
+type queryParamsKey struct{}
+
+// withQueryParams returns a context that adds params to the query of the
+// console calls made with it, for the APIs whose client methods do not take
+// paging options.
+func withQueryParams(ctx context.Context, params url.Values) context.Context {
+	return context.WithValue(ctx, queryParamsKey{}, params)
+}
+
+// pageParams returns the query parameters of one page of a list.
+func pageParams(opts listOptions, limit, offset int64) url.Values {
+	params := url.Values{}
+	params.Set("limit", strconv.FormatInt(limit, 10))
+	params.Set("offset", strconv.FormatInt(offset, 10))
+	if opts.Sort != "" {
+		params.Set("sort", opts.Sort)
+	}
+	if opts.Filter != "" {
+		params.Set("filter", opts.Filter)
+	}
+	return params
+}
+
+// queryTransport adds the query parameters carried by the request context
+// through withQueryParams.
+type queryTransport struct {
+	next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *queryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	params, ok := req.Context().Value(queryParamsKey{}).(url.Values)
+	if !ok {
+		return t.next.RoundTrip(req)
+	}
+
+	out := req.Clone(req.Context())
+	query := out.URL.Query()
+	for name, values := range params {
+		query[name] = values
+	}
+	out.URL.RawQuery = query.Encode()
+	return t.next.RoundTrip(out)
+}
+
+// optionalString returns s as an optional client parameter, unset when s is
+// empty.
+func optionalString(s string) optional.String {
+	if s == "" {
+		return optional.EmptyString()
+	}
+	return optional.NewString(s)
+}
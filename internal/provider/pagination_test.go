@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestListAll(t *testing.T) {
+	defer func(size int64) { listPageSize = size }(listPageSize)
+	listPageSize = 2
+
+	objects := []int{0, 1, 2, 3, 4}
+	tests := map[string]struct {
+		limit     int64
+		keep      func(int) bool
+		ignore    bool
+		want      []int
+		wantPages [][2]int64
+	}{
+		"every page": {
+			want:      []int{0, 1, 2, 3, 4},
+			wantPages: [][2]int64{{2, 0}, {2, 2}, {2, 4}},
+		},
+		"limit": {
+			limit:     3,
+			want:      []int{0, 1, 2},
+			wantPages: [][2]int64{{2, 0}, {1, 2}},
+		},
+		"limit of kept objects": {
+			limit:     2,
+			keep:      func(i int) bool { return i%2 == 1 },
+			want:      []int{1, 3},
+			wantPages: [][2]int64{{2, 0}, {2, 2}},
+		},
+		"paging ignored": {
+			ignore:    true,
+			want:      []int{0, 1, 2, 3, 4},
+			wantPages: [][2]int64{{2, 0}},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var pages [][2]int64
+			got, err := listAll(tt.limit, func(limit, offset int64) ([]int, int32, error) {
+				pages = append(pages, [2]int64{limit, offset})
+				if tt.ignore {
+					return objects, 0, nil
+				}
+				end := offset + limit
+				if end > int64(len(objects)) {
+					end = int64(len(objects))
+				}
+				return objects[offset:end], int32(len(objects)), nil
+			}, tt.keep)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(pages, tt.wantPages) {
+				t.Errorf("got pages %v, want %v", pages, tt.wantPages)
+			}
+		})
+	}
+}
+
+func TestListAll_endless(t *testing.T) {
+	defer func(size int64, pages int) { listPageSize, maxListPages = size, pages }(listPageSize, maxListPages)
+	listPageSize = 2
+	maxListPages = 5
+
+	// The console ignores the offset and returns full pages without a count.
+	calls := 0
+	got, err := listAll(0, func(limit, offset int64) ([]int, int32, error) {
+		calls++
+		return []int{0, 1}, 0, nil
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, []int{0, 1}) || calls != 2 {
+		t.Errorf("got %v after %d pages, want [0 1] after 2", got, calls)
+	}
+
+	// An empty page ends the list.
+	got, err = listAll(0, func(limit, offset int64) ([]int, int32, error) {
+		if offset >= 4 {
+			return nil, 0, nil
+		}
+		return []int{int(offset), int(offset) + 1}, 0, nil
+	}, nil)
+	if err != nil || !reflect.DeepEqual(got, []int{0, 1, 2, 3}) {
+		t.Errorf("got %v, %v, want [0 1 2 3]", got, err)
+	}
+
+	// A list that never ends is cut off.
+	calls = 0
+	if _, err := listAll(0, func(limit, offset int64) ([]int, int32, error) {
+		calls++
+		return []int{int(offset), int(offset) + 1}, 0, nil
+	}, nil); err == nil || calls != 5 {
+		t.Errorf("got %v after %d pages, want an error after 5", err, calls)
+	}
+}
+
+func TestListAll_cappedPages(t *testing.T) {
+	// The console returns at most 10 objects per page, fewer than requested.
+	console := newFakeConsole(t)
+	console.maxPage = 10
+	for i := 0; i < 25; i++ {
+		console.Seed("application", map[string]any{"appname": fmt.Sprintf("app-%d", i)})
+	}
+
+	applications, err := listApplications(console.Client(), context.Background(), applicationFilter{}, listOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applications) != 25 {
+		t.Errorf("got %d applications, want 25", len(applications))
+	}
+}
+
+func TestQueryTransport(t *testing.T) {
+	query := make(chan url.Values, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query <- r.URL.Query()
+	}))
+	t.Cleanup(srv.Close)
+
+	client := &http.Client{Transport: &queryTransport{next: http.DefaultTransport}}
+	ctx := withQueryParams(context.Background(), pageParams(listOptions{Sort: "name:asc"}, 50, 100))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/cloudcredential?filter=x", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	want := url.Values{"filter": {"x"}, "limit": {"50"}, "offset": {"100"}, "sort": {"name:asc"}}
+	if got := <-query; !reflect.DeepEqual(got, want) {
+		t.Errorf("got query %v, want %v", got, want)
+	}
+}
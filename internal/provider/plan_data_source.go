@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -22,6 +23,25 @@ type planDataSource struct {
 	authCtx context.Context
 }
 
+// tf go model
+type planDataSourceModel struct {
+	Appname          types.String              `tfsdk:"appname"`
+	Appliance        types.String              `tfsdk:"appliance"`
+	Description      types.String              `tfsdk:"description"`
+	Application      *ApplicationResourceModel `tfsdk:"application"`
+	Slt              *templateResourceRefModel `tfsdk:"slt"`
+	Modifydate       types.Int64               `tfsdk:"modifydate"`
+	Scheduleoff      types.String              `tfsdk:"scheduleoff"`
+	Slp              *profileResourceRefModel  `tfsdk:"slp"`
+	Logexpirationoff types.Bool                `tfsdk:"logexpirationoff"`
+	Dedupasyncoff    types.String              `tfsdk:"dedupasyncoff"`
+	Expirationoff    types.String              `tfsdk:"expirationoff"`
+	ID               types.String              `tfsdk:"id"`
+	Href             types.String              `tfsdk:"href"`
+	Syncdate         types.Int64               `tfsdk:"syncdate"`
+	Stale            types.Bool                `tfsdk:"stale"`
+}
+
 // NewPlanDataSource - Datasource for SLA Profile
 func NewPlanDataSource() datasource.DataSource {
 	return &planDataSource{}
@@ -46,8 +66,17 @@ func (d *planDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 		MarkdownDescription: "This data source can be used to read information about a backup plan. It displays the backup plan ID as shown in the **Management console** > **Manage** > **Backup Plans** page.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "The unique ID of this resource backup plan ID can also be referred as sla ID’s.",
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The unique ID of this resource backup plan ID can also be referred as sla ID’s. Either `id` or `appname` must be set.",
+			},
+			"appname": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Provide the name of the protected application to look up its backup plan instead of `id`. Backup plans have no name of their own.",
+			},
+			"appliance": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Provide the ID, cluster ID or name of the backup/recovery appliance to restrict a lookup by `appname` to that appliance.",
 			},
 			"href": schema.StringAttribute{
 				Computed:            true,
@@ -189,15 +218,31 @@ func (d *planDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 
 func (d *planDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 
-	var state planResourceModel
+	if !checkProviderConfigured(d.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state planDataSourceModel
 	// Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if !checkLookupKeys(state.ID, state.Appname, "appname", &resp.Diagnostics) {
+		return
+	}
+
+	slaID := state.ID.ValueString()
+	if slaID == "" {
+		var ok bool
+		if slaID, ok = d.lookupID(state, &resp.Diagnostics); !ok {
+			return
+		}
+	}
 
-	sla, res, err := d.client.SLAApi.GetSla(d.authCtx, state.ID.ValueString())
+	sla, res, err := d.client.SLAApi.GetSla(d.authCtx, slaID)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addConsoleError(&resp.Diagnostics,
 			"Unable to Read BackupDR SLA",
-			err.Error(),
+			"Could not read SLA with ID "+slaID+".",
+			res, err, nil,
 		)
 		return
 	}
@@ -210,7 +255,9 @@ func (d *planDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	}
 
 	// Map response body to model
-	state = planResourceModel{
+	state = planDataSourceModel{
+		Appname:     state.Appname,
+		Appliance:   state.Appliance,
 		ID:          types.StringValue(sla.Id),
 		Href:        types.StringValue(sla.Href),
 		Description: types.StringValue(sla.Description),
@@ -257,3 +304,29 @@ func (d *planDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 }
+
+// lookupID resolves the backup plan ID from the name of the application it
+// protects, optionally restricted to one appliance.
+func (d *planDataSource) lookupID(state planDataSourceModel, diags *diag.Diagnostics) (string, bool) {
+	filter := applicationFilter{
+		Appname:   state.Appname.ValueString(),
+		Appliance: state.Appliance.ValueString(),
+	}
+	applications, err := listApplications(d.client, d.authCtx, filter, listOptions{})
+	if err != nil {
+		addConsoleError(diags,
+			"Unable to Read BackupDR SLA",
+			"Could not list applications matching "+filter.String()+".",
+			nil, err, nil,
+		)
+		return "", false
+	}
+
+	var candidates []lookupCandidate
+	for _, application := range applications {
+		if application.Sla != nil && application.Sla.Id != "" {
+			candidates = append(candidates, lookupCandidate{ID: application.Sla.Id, Name: application.Appname})
+		}
+	}
+	return resolveLookupID("Backup Plan", "protected application "+filter.String(), candidates, diags)
+}
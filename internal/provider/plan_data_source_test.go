@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccPlanDataSource(t *testing.T) {
+	console := newFakeConsole(t)
+	profileID := console.Seed("slp", map[string]any{"name": "test-profile"})
+	templateID := console.Seed("slt", map[string]any{"name": "test-template"})
+	applicationID := console.Seed("application", map[string]any{
+		"appname": "web-1",
+		"apptype": "GCPInstance",
+		"cluster": map[string]any{"id": "1415", "clusterid": "144292692833"},
+	})
+	planID := console.Seed("sla", map[string]any{
+		"description": "web backups",
+		"application": map[string]any{"id": applicationID},
+		"slp":         map[string]any{"id": profileID},
+		"slt":         map[string]any{"id": templateID},
+	})
+	console.Update("application", applicationID, map[string]any{"sla": map[string]any{"id": planID}})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(console) + `
+data "backupdr_plan" "by_id" {
+  id = "` + planID + `"
+}
+
+data "backupdr_plan" "by_application" {
+  appname   = "web-1"
+  appliance = "144292692833"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backupdr_plan.by_id", "description", "web backups"),
+					resource.TestCheckResourceAttr("data.backupdr_plan.by_id", "application.appname", "web-1"),
+					resource.TestCheckResourceAttr("data.backupdr_plan.by_id", "slt.id", templateID),
+					resource.TestCheckResourceAttr("data.backupdr_plan.by_id", "slp.name", "test-profile"),
+					resource.TestCheckResourceAttr("data.backupdr_plan.by_application", "id", planID),
+				),
+			},
+		},
+	})
+}
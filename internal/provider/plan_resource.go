@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -21,6 +22,19 @@ var (
 	_ resource.ResourceWithImportState = &planResource{}
 )
 
+// planConsoleFields maps the fields of backup plan requests to the attributes
+// they are set from, so console errors are reported against them.
+var planConsoleFields = map[string]path.Path{
+	"description":      path.Root("description"),
+	"logexpirationoff": path.Root("logexpirationoff"),
+	"dedupasyncoff":    path.Root("dedupasyncoff"),
+	"expirationoff":    path.Root("expirationoff"),
+	"scheduleoff":      path.Root("scheduleoff"),
+	"application":      path.Root("application").AtName("id"),
+	"slp":              path.Root("slp").AtName("id"),
+	"slt":              path.Root("slt").AtName("id"),
+}
+
 // NewPlanResource to create SLA Profiles
 func NewPlanResource() resource.Resource {
 	return &planResource{}
@@ -38,7 +52,7 @@ func (r *planResource) Metadata(_ context.Context, req resource.MetadataRequest,
 }
 
 // Schema defines the schema for the resource.
-func (r *planResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *planResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Backup plans are the binding of templates ids, profiles ids to application ids management console shows when an application (VM, Filesystem or Database) is protected. Templates define how often to back up application data, how long to retain the application data backups, and where and how to replicate the application's data backups. Use the backup templates  to create policies and resource profiles to specify which backup/recovery appliance is used to manage the backup plan. A backup plans violation occurs when data is not being backed up according to the boundaries you have set in a templates policy. For more information, see [Backup plan](https://cloud.google.com/backup-disaster-recovery/docs/concepts/backup-plan).",
 		Attributes: map[string]schema.Attribute{
@@ -183,6 +197,9 @@ func (r *planResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": resourceTimeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -198,6 +215,10 @@ func (r *planResource) Configure(_ context.Context, req resource.ConfigureReques
 
 // Create a new resource.
 func (r *planResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !checkProviderConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	// Retrieve values from plan
 	var plan planResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -206,6 +227,14 @@ func (r *planResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, authCtx, cancel := withTimeout(ctx, r.authCtx, createTimeout)
+	defer cancel()
+
 	reqSla := backupdr.SlaRest{
 		Description:      plan.Description.ValueString(),
 		Logexpirationoff: plan.Logexpirationoff.ValueBool(),
@@ -238,11 +267,12 @@ func (r *planResource) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 
 	// Create new sla
-	respObject, _, err := r.client.SLAApi.CreateSla(r.authCtx, &reqBody)
+	respObject, res, err := r.client.SLAApi.CreateSla(authCtx, &reqBody)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addConsoleError(&resp.Diagnostics,
 			"Error creating SLA",
-			"Could not create SLA, unexpected error: "+err.Error(),
+			"Could not create SLA.",
+			res, err, planConsoleFields,
 		)
 		return
 	}
@@ -258,29 +288,35 @@ func (r *planResource) Create(ctx context.Context, req resource.CreateRequest, r
 	plan.Expirationoff = types.StringValue(respObject.Expirationoff)
 	plan.Dedupasyncoff = types.StringValue(respObject.Dedupasyncoff)
 	plan.Logexpirationoff = types.BoolValue(respObject.Logexpirationoff)
-	plan.Scheduleoff = types.StringValue(respObject.Scheduleoff)
-
-	plan.Application.Appname = types.StringValue(respObject.Application.Appname)
-	plan.Application.Apptype = types.StringValue(respObject.Application.Apptype)
-	plan.Application.Description = types.StringValue(respObject.Application.Description)
-	plan.Application.Href = types.StringValue(respObject.Application.Href)
-	plan.Application.Name = types.StringValue(respObject.Application.Name)
-	plan.Application.Stale = types.BoolValue(respObject.Application.Stale)
-	plan.Application.Syncdate = types.Int64Value(respObject.Application.Syncdate)
-	plan.Application.Href = types.StringValue(respObject.Application.Href)
-	plan.Application.Href = types.StringValue(respObject.Application.Href)
-
-	plan.Slp.Href = types.StringValue(respObject.Slp.Href)
-	plan.Slp.Cid = types.StringValue(respObject.Slp.Cid)
-	plan.Slp.Name = types.StringValue(respObject.Slp.Name)
-	plan.Slp.Stale = types.BoolValue(respObject.Slp.Stale)
-	plan.Slp.Syncdate = types.Int64Value(respObject.Slp.Syncdate)
-
-	plan.Slt.Href = types.StringValue(respObject.Slt.Href)
-	plan.Slt.Name = types.StringValue(respObject.Slt.Name)
-	plan.Slt.Override = types.StringValue(respObject.Slt.Override)
-	plan.Slt.Sourcename = types.StringValue(respObject.Slt.Sourcename)
-	plan.Slt.Stale = types.BoolValue(respObject.Slt.Stale)
+	plan.Scheduleoff = optionalStringValue(plan.Scheduleoff, respObject.Scheduleoff, "false")
+
+	if plan.Application != nil && respObject.Application != nil {
+		plan.Application.Appname = types.StringValue(respObject.Application.Appname)
+		plan.Application.Apptype = types.StringValue(respObject.Application.Apptype)
+		plan.Application.Description = types.StringValue(respObject.Application.Description)
+		plan.Application.Href = types.StringValue(respObject.Application.Href)
+		plan.Application.Name = types.StringValue(respObject.Application.Name)
+		plan.Application.Stale = types.BoolValue(respObject.Application.Stale)
+		plan.Application.Syncdate = types.Int64Value(respObject.Application.Syncdate)
+		plan.Application.Href = types.StringValue(respObject.Application.Href)
+		plan.Application.Href = types.StringValue(respObject.Application.Href)
+	}
+
+	if plan.Slp != nil && respObject.Slp != nil {
+		plan.Slp.Href = types.StringValue(respObject.Slp.Href)
+		plan.Slp.Cid = types.StringValue(respObject.Slp.Cid)
+		plan.Slp.Name = types.StringValue(respObject.Slp.Name)
+		plan.Slp.Stale = types.BoolValue(respObject.Slp.Stale)
+		plan.Slp.Syncdate = types.Int64Value(respObject.Slp.Syncdate)
+	}
+
+	if plan.Slt != nil && respObject.Slt != nil {
+		plan.Slt.Href = types.StringValue(respObject.Slt.Href)
+		plan.Slt.Name = types.StringValue(respObject.Slt.Name)
+		plan.Slt.Override = types.StringValue(respObject.Slt.Override)
+		plan.Slt.Sourcename = types.StringValue(respObject.Slt.Sourcename)
+		plan.Slt.Stale = types.BoolValue(respObject.Slt.Stale)
+	}
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
@@ -292,6 +328,10 @@ func (r *planResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 // Read resource information.
 func (r *planResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if unknownProviderConfig(ctx, r.client) {
+		return
+	}
+
 	// Get current state
 	var state planResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -300,12 +340,26 @@ func (r *planResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, authCtx, cancel := withTimeout(ctx, r.authCtx, readTimeout)
+	defer cancel()
+
 	// Get refreshed values
-	respObject, _, err := r.client.SLAApi.GetSla(r.authCtx, state.ID.ValueString())
+	respObject, res, err := r.client.SLAApi.GetSla(authCtx, state.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
+		if isNotFound(res, err) {
+			tflog.Warn(ctx, "SLA no longer exists, removing it from state", map[string]any{"id": state.ID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addConsoleError(&resp.Diagnostics,
 			"Error Reading SLA",
-			"Could not read SLA with ID "+state.ID.ValueString()+": "+err.Error(),
+			"Could not read SLA with ID "+state.ID.ValueString()+".",
+			res, err, nil,
 		)
 		return
 	}
@@ -320,29 +374,49 @@ func (r *planResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	state.Expirationoff = types.StringValue(respObject.Expirationoff)
 	state.Dedupasyncoff = types.StringValue(respObject.Dedupasyncoff)
 	state.Logexpirationoff = types.BoolValue(respObject.Logexpirationoff)
-	state.Scheduleoff = types.StringValue(respObject.Scheduleoff)
-
-	state.Application.Appname = types.StringValue(respObject.Application.Appname)
-	state.Application.Apptype = types.StringValue(respObject.Application.Apptype)
-	state.Application.Description = types.StringValue(respObject.Application.Description)
-	state.Application.Href = types.StringValue(respObject.Application.Href)
-	state.Application.Name = types.StringValue(respObject.Application.Name)
-	state.Application.Stale = types.BoolValue(respObject.Application.Stale)
-	state.Application.Syncdate = types.Int64Value(respObject.Application.Syncdate)
-	state.Application.Href = types.StringValue(respObject.Application.Href)
-	state.Application.Href = types.StringValue(respObject.Application.Href)
-
-	state.Slp.Href = types.StringValue(respObject.Slp.Href)
-	state.Slp.Cid = types.StringValue(respObject.Slp.Cid)
-	state.Slp.Name = types.StringValue(respObject.Slp.Name)
-	state.Slp.Stale = types.BoolValue(respObject.Slp.Stale)
-	state.Slp.Syncdate = types.Int64Value(respObject.Slp.Syncdate)
-
-	state.Slt.Href = types.StringValue(respObject.Slt.Href)
-	state.Slt.Name = types.StringValue(respObject.Slt.Name)
-	state.Slt.Override = types.StringValue(respObject.Slt.Override)
-	state.Slt.Sourcename = types.StringValue(respObject.Slt.Sourcename)
-	state.Slt.Stale = types.BoolValue(respObject.Slt.Stale)
+	state.Scheduleoff = optionalStringValue(state.Scheduleoff, respObject.Scheduleoff, "false")
+	state.Description = optionalStringValue(state.Description, respObject.Description)
+
+	// An imported plan has no references in state yet.
+	if respObject.Application != nil {
+		if state.Application == nil {
+			state.Application = &ApplicationResourceModel{}
+		}
+		state.Application.ID = types.StringValue(respObject.Application.Id)
+		state.Application.Appname = types.StringValue(respObject.Application.Appname)
+		state.Application.Apptype = types.StringValue(respObject.Application.Apptype)
+		state.Application.Description = types.StringValue(respObject.Application.Description)
+		state.Application.Href = types.StringValue(respObject.Application.Href)
+		state.Application.Name = types.StringValue(respObject.Application.Name)
+		state.Application.Stale = types.BoolValue(respObject.Application.Stale)
+		state.Application.Syncdate = types.Int64Value(respObject.Application.Syncdate)
+		state.Application.Href = types.StringValue(respObject.Application.Href)
+		state.Application.Href = types.StringValue(respObject.Application.Href)
+	}
+
+	if respObject.Slp != nil {
+		if state.Slp == nil {
+			state.Slp = &profileResourceRefModel{}
+		}
+		state.Slp.ID = types.StringValue(respObject.Slp.Id)
+		state.Slp.Href = types.StringValue(respObject.Slp.Href)
+		state.Slp.Cid = types.StringValue(respObject.Slp.Cid)
+		state.Slp.Name = types.StringValue(respObject.Slp.Name)
+		state.Slp.Stale = types.BoolValue(respObject.Slp.Stale)
+		state.Slp.Syncdate = types.Int64Value(respObject.Slp.Syncdate)
+	}
+
+	if respObject.Slt != nil {
+		if state.Slt == nil {
+			state.Slt = &templateResourceRefModel{}
+		}
+		state.Slt.ID = types.StringValue(respObject.Slt.Id)
+		state.Slt.Href = types.StringValue(respObject.Slt.Href)
+		state.Slt.Name = types.StringValue(respObject.Slt.Name)
+		state.Slt.Override = types.StringValue(respObject.Slt.Override)
+		state.Slt.Sourcename = types.StringValue(respObject.Slt.Sourcename)
+		state.Slt.Stale = types.BoolValue(respObject.Slt.Stale)
+	}
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
@@ -353,6 +427,10 @@ func (r *planResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *planResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !checkProviderConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	// Retrieve values from plan
 	var plan planResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -361,6 +439,14 @@ func (r *planResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, authCtx, cancel := withTimeout(ctx, r.authCtx, updateTimeout)
+	defer cancel()
+
 	reqSla := backupdr.SlaRest{
 		Id:               plan.ID.ValueString(),
 		Description:      plan.Description.ValueString(),
@@ -387,11 +473,12 @@ func (r *planResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	}
 
 	// Update existing order
-	respObject, res, err := r.client.SLAApi.UpdateSla(r.authCtx, plan.ID.ValueString(), &reqBody)
+	respObject, res, err := r.client.SLAApi.UpdateSla(authCtx, plan.ID.ValueString(), &reqBody)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Updating SLA ",
-			"An unexpected error occurred when updating the BackupDR SLA , unexpected error: "+err.Error(),
+		addConsoleError(&resp.Diagnostics,
+			"Error Updating SLA",
+			"Could not update SLA with ID "+plan.ID.ValueString()+".",
+			res, err, planConsoleFields,
 		)
 		return
 	}
@@ -415,29 +502,35 @@ func (r *planResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	plan.Expirationoff = types.StringValue(respObject.Expirationoff)
 	plan.Dedupasyncoff = types.StringValue(respObject.Dedupasyncoff)
 	plan.Logexpirationoff = types.BoolValue(respObject.Logexpirationoff)
-	plan.Scheduleoff = types.StringValue(respObject.Scheduleoff)
-
-	plan.Application.Appname = types.StringValue(respObject.Application.Appname)
-	plan.Application.Apptype = types.StringValue(respObject.Application.Apptype)
-	plan.Application.Description = types.StringValue(respObject.Application.Description)
-	plan.Application.Href = types.StringValue(respObject.Application.Href)
-	plan.Application.Name = types.StringValue(respObject.Application.Name)
-	plan.Application.Stale = types.BoolValue(respObject.Application.Stale)
-	plan.Application.Syncdate = types.Int64Value(respObject.Application.Syncdate)
-	plan.Application.Href = types.StringValue(respObject.Application.Href)
-	plan.Application.Href = types.StringValue(respObject.Application.Href)
-
-	plan.Slp.Href = types.StringValue(respObject.Slp.Href)
-	plan.Slp.Cid = types.StringValue(respObject.Slp.Cid)
-	plan.Slp.Name = types.StringValue(respObject.Slp.Name)
-	plan.Slp.Stale = types.BoolValue(respObject.Slp.Stale)
-	plan.Slp.Syncdate = types.Int64Value(respObject.Slp.Syncdate)
-
-	plan.Slt.Href = types.StringValue(respObject.Slt.Href)
-	plan.Slt.Name = types.StringValue(respObject.Slt.Name)
-	plan.Slt.Override = types.StringValue(respObject.Slt.Override)
-	plan.Slt.Sourcename = types.StringValue(respObject.Slt.Sourcename)
-	plan.Slt.Stale = types.BoolValue(respObject.Slt.Stale)
+	plan.Scheduleoff = optionalStringValue(plan.Scheduleoff, respObject.Scheduleoff, "false")
+
+	if plan.Application != nil && respObject.Application != nil {
+		plan.Application.Appname = types.StringValue(respObject.Application.Appname)
+		plan.Application.Apptype = types.StringValue(respObject.Application.Apptype)
+		plan.Application.Description = types.StringValue(respObject.Application.Description)
+		plan.Application.Href = types.StringValue(respObject.Application.Href)
+		plan.Application.Name = types.StringValue(respObject.Application.Name)
+		plan.Application.Stale = types.BoolValue(respObject.Application.Stale)
+		plan.Application.Syncdate = types.Int64Value(respObject.Application.Syncdate)
+		plan.Application.Href = types.StringValue(respObject.Application.Href)
+		plan.Application.Href = types.StringValue(respObject.Application.Href)
+	}
+
+	if plan.Slp != nil && respObject.Slp != nil {
+		plan.Slp.Href = types.StringValue(respObject.Slp.Href)
+		plan.Slp.Cid = types.StringValue(respObject.Slp.Cid)
+		plan.Slp.Name = types.StringValue(respObject.Slp.Name)
+		plan.Slp.Stale = types.BoolValue(respObject.Slp.Stale)
+		plan.Slp.Syncdate = types.Int64Value(respObject.Slp.Syncdate)
+	}
+
+	if plan.Slt != nil && respObject.Slt != nil {
+		plan.Slt.Href = types.StringValue(respObject.Slt.Href)
+		plan.Slt.Name = types.StringValue(respObject.Slt.Name)
+		plan.Slt.Override = types.StringValue(respObject.Slt.Override)
+		plan.Slt.Sourcename = types.StringValue(respObject.Slt.Sourcename)
+		plan.Slt.Stale = types.BoolValue(respObject.Slt.Stale)
+	}
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -447,6 +540,10 @@ func (r *planResource) Update(ctx context.Context, req resource.UpdateRequest, r
 }
 
 func (r *planResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !checkProviderConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	// Retrieve values from state
 	var state planResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -455,18 +552,31 @@ func (r *planResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, authCtx, cancel := withTimeout(ctx, r.authCtx, deleteTimeout)
+	defer cancel()
+
 	// Delete existing SLA profile
-	_, err := r.client.SLAApi.DeleteSla(r.authCtx, state.ID.ValueString())
+	res, err := r.client.SLAApi.DeleteSla(authCtx, state.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addConsoleError(&resp.Diagnostics,
 			"Error Deleting SLA",
-			"Could not delete sla, unexpected error: "+err.Error(),
+			"Could not delete SLA with ID "+state.ID.ValueString()+".",
+			res, err, nil,
 		)
 		return
 	}
 }
 
 func (r *planResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if !checkProviderConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	// Retrieve import ID and save to id attribute
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
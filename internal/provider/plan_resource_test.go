@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccPlanResourceConfig(console *fakeConsole, applicationID, description string) string {
+	return testAccProviderConfig(console) + fmt.Sprintf(`
+resource "backupdr_profile" "test" {
+  name            = "test-profile"
+  cid             = "4123"
+  performancepool = "act_per_pool000"
+}
+
+resource "backupdr_template" "test" {
+  name = "test-template"
+}
+
+resource "backupdr_plan" "test" {
+  description = %q
+  application = {
+    id = %q
+  }
+  slp = {
+    id = backupdr_profile.test.id
+  }
+  slt = {
+    id = backupdr_template.test.id
+  }
+}
+`, description, applicationID)
+}
+
+func TestAccPlanResource(t *testing.T) {
+	console := newFakeConsole(t)
+	applicationID := console.Seed("application", map[string]any{
+		"appname":    "web-1",
+		"apptype":    "GCPInstance",
+		"uniquename": "6279367390186066186",
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             console.CheckDestroyed("sla"),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccPlanResourceConfig(console, applicationID, "first"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("backupdr_plan.test", "application.id", applicationID),
+					resource.TestCheckResourceAttr("backupdr_plan.test", "application.appname", "web-1"),
+					resource.TestCheckResourceAttr("backupdr_plan.test", "slt.name", "test-template"),
+					resource.TestCheckResourceAttr("backupdr_plan.test", "slp.name", "test-profile"),
+					resource.TestCheckResourceAttrSet("backupdr_plan.test", "id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "backupdr_plan.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccPlanResourceConfig(console, applicationID, "second"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("backupdr_plan.test", "description", "second"),
+					console.CheckField("sla", "backupdr_plan.test", "description", "second"),
+				),
+			},
+			// Drift testing
+			{
+				PreConfig: func() {
+					id := console.Find("sla", "description", "second")
+					console.Update("sla", id, map[string]any{"description": "changed in console"})
+				},
+				Config:             testAccPlanResourceConfig(console, applicationID, "second"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Removed from state when deleted in the console
+			{
+				PreConfig: func() {
+					console.Delete("sla", console.Find("sla", "description", "changed in console"))
+				},
+				Config:             testAccPlanResourceConfig(console, applicationID, "second"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestPlanResource_readKeepsConsoleDefaultsNull(t *testing.T) {
+	console := newFakeConsole(t)
+	id := console.Seed("sla", map[string]any{"description": ""})
+
+	state := readResource(t, &planResource{client: console.Client(), authCtx: context.Background()}, map[string]any{
+		"id": types.StringValue(id),
+	})
+
+	var got planResourceModel
+	if diags := state.Get(context.Background(), &got); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if !got.Scheduleoff.IsNull() {
+		t.Errorf("got scheduleoff %s, want null", got.Scheduleoff)
+	}
+}
@@ -2,11 +2,14 @@ package provider
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/antihax/optional"
 	backupdr "github.com/umeshkumhar/backupdr-client"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -46,12 +49,14 @@ func (d *profileDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 		MarkdownDescription: "This data source can be used to read information about a backup profile. It displays the resource profile ID as shown in the **Management console** > **Backup Plans** > **Profiles** page.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "Provide the ID of the resource.",
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Provide the ID of the resource. Either `id` or `name` must be set.",
 			},
 			"name": schema.StringAttribute{
+				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "It displays the name of the OnVault pool used for resource profile.",
+				MarkdownDescription: "Provide the name of the resource profile to look it up instead of `id`. It displays the name of the resource profile.",
 			},
 			"href": schema.StringAttribute{
 				Computed:            true,
@@ -78,8 +83,9 @@ func (d *profileDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 				MarkdownDescription: "It displays the ID of the cluster - It is not the same as cluster ID.",
 			},
 			"clusterid": schema.StringAttribute{
+				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "It displays the backup/recovery appliance ID.",
+				MarkdownDescription: "Provide the backup/recovery appliance ID to restrict a lookup by `name` to that appliance. It displays the backup/recovery appliance ID.",
 			},
 			"performancepool": schema.StringAttribute{
 				Computed:            true,
@@ -93,6 +99,10 @@ func (d *profileDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 				Computed:            true,
 				MarkdownDescription: "It displays the primary backup/recovery appliance name.",
 			},
+			"dedupasyncnode": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "It displays the dedupe async node name.",
+			},
 			"modifydate": schema.Int64Attribute{
 				Computed:            true,
 				MarkdownDescription: "It displays the date when the resource profile details are modified.",
@@ -175,15 +185,31 @@ func (d *profileDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 
 func (d *profileDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 
-	var state profileResourceModel
+	if !checkProviderConfigured(d.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state profileDataSourceModel
 	// Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if !checkLookupKeys(state.ID, state.Name, "name", &resp.Diagnostics) {
+		return
+	}
+
+	slpID := state.ID.ValueString()
+	if slpID == "" {
+		var ok bool
+		if slpID, ok = d.lookupID(state, &resp.Diagnostics); !ok {
+			return
+		}
+	}
 
-	slp, res, err := d.client.SLAProfileApi.GetSlp(d.authCtx, state.ID.ValueString())
+	slp, res, err := d.client.SLAProfileApi.GetSlp(d.authCtx, slpID)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addConsoleError(&resp.Diagnostics,
 			"Unable to Read BackupDR SLA Profile",
-			err.Error(),
+			"Could not read SLA Profile with ID "+slpID+".",
+			res, err, nil,
 		)
 		return
 	}
@@ -196,7 +222,7 @@ func (d *profileDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	}
 
 	// Map response body to model
-	slpState := profileResourceModel{
+	slpState := profileDataSourceModel{
 		ID:              types.StringValue(slp.Id),
 		Href:            types.StringValue(slp.Href),
 		Name:            types.StringValue(slp.Name),
@@ -253,3 +279,36 @@ func (d *profileDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	}
 
 }
+
+// lookupID resolves the resource profile ID from its name, optionally
+// restricted to one appliance.
+func (d *profileDataSource) lookupID(state profileDataSourceModel, diags *diag.Diagnostics) (string, bool) {
+	name := state.Name.ValueString()
+	filter := backupdr.SLAProfileApiListSlpsOpts{
+		Filter: optional.NewString("name:==" + name),
+	}
+	slps, res, err := d.client.SLAProfileApi.ListSlps(d.authCtx, &filter)
+	if err != nil {
+		addConsoleError(diags,
+			"Unable to Read BackupDR SLA Profile",
+			"Could not list SLA Profiles named "+name+".",
+			res, err, nil,
+		)
+		return "", false
+	}
+
+	description := fmt.Sprintf("name %q", name)
+	clusterID := state.Clusterid.ValueString()
+	if clusterID != "" {
+		description += fmt.Sprintf(" on appliance %q", clusterID)
+	}
+
+	var candidates []lookupCandidate
+	for _, slp := range slps.Items {
+		if slp.Name != name || (clusterID != "" && slp.Clusterid != clusterID) {
+			continue
+		}
+		candidates = append(candidates, lookupCandidate{ID: slp.Id, Name: slp.Name})
+	}
+	return resolveLookupID("Resource Profile", description, candidates, diags)
+}
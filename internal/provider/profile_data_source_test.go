@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccProfileDataSource(t *testing.T) {
+	console := newFakeConsole(t)
+	profileID := console.Seed("slp", map[string]any{
+		"name":            "test-profile",
+		"clusterid":       "144292692833",
+		"performancepool": "act_per_pool000",
+	})
+	console.Seed("slp", map[string]any{"name": "test-profile", "clusterid": "144292692834"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(console) + `
+data "backupdr_profile" "by_id" {
+  id = "` + profileID + `"
+}
+
+data "backupdr_profile" "by_name" {
+  name      = "test-profile"
+  clusterid = "144292692833"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backupdr_profile.by_id", "name", "test-profile"),
+					resource.TestCheckResourceAttr("data.backupdr_profile.by_id", "performancepool", "act_per_pool000"),
+					resource.TestCheckResourceAttr("data.backupdr_profile.by_name", "id", profileID),
+				),
+			},
+		},
+	})
+}
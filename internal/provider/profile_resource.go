@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -24,6 +25,25 @@ var (
 	_ resource.ResourceWithImportState = &profileResource{}
 )
 
+// defaultPerformancePool is the snapshot pool the console uses for profiles
+// that do not set one.
+const defaultPerformancePool = "act_per_pool000"
+
+// profileConsoleFields maps the fields of resource profile requests to the attributes
+// they are set from, so console errors are reported against them.
+var profileConsoleFields = map[string]path.Path{
+	"name":            path.Root("name"),
+	"description":     path.Root("description"),
+	"cid":             path.Root("cid"),
+	"performancepool": path.Root("performancepool"),
+	"localnode":       path.Root("localnode"),
+	"remotenode":      path.Root("remotenode"),
+	"vaultpool":       path.Root("vaultpool").AtName("id"),
+	"vaultpool2":      path.Root("vaultpool2").AtName("id"),
+	"vaultpool3":      path.Root("vaultpool3").AtName("id"),
+	"vaultpool4":      path.Root("vaultpool4").AtName("id"),
+}
+
 // NewProfileResource to create SLA Profiles
 func NewProfileResource() resource.Resource {
 	return &profileResource{}
@@ -41,7 +61,7 @@ func (r *profileResource) Metadata(_ context.Context, req resource.MetadataReque
 }
 
 // Schema defines the schema for the resource.
-func (r *profileResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *profileResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "A resource profile specifies the storage media for backups of application and VM data. The template and the resource profile that make up the backup plan dictate the type of application data policies to perform and where to store the application data backups (which storage pool is used). Resource Profiles define which snapshot pool (if needed) is used and which remote appliance data is replicated. " +
 			"In addition to templates, you also create resource profiles in the backup plans menu. Profiles define where to store data. Data can be stored in the following: \n" +
@@ -70,7 +90,11 @@ func (r *profileResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				MarkdownDescription: "Provide a description for the resource profile.",
 			},
 			"cid": schema.StringAttribute{
-				Optional:            true,
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 				MarkdownDescription: "Provide the ID of the cluster - It is not the same as cluster ID.",
 			},
 			"performancepool": schema.StringAttribute{
@@ -78,7 +102,11 @@ func (r *profileResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				MarkdownDescription: "Provide a name of the snapshot (performance) pool. The default is act_per_pool000.",
 			},
 			"localnode": schema.StringAttribute{
-				Optional:            true,
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 				MarkdownDescription: "Provide the primary backup/recovery appliance name.",
 			},
 			"remotenode": schema.StringAttribute{
@@ -184,6 +212,9 @@ func (r *profileResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": resourceTimeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -199,6 +230,10 @@ func (r *profileResource) Configure(_ context.Context, req resource.ConfigureReq
 
 // Create a new resource.
 func (r *profileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !checkProviderConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	// Retrieve values from plan
 	var plan profileResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -207,6 +242,14 @@ func (r *profileResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, authCtx, cancel := withTimeout(ctx, r.authCtx, createTimeout)
+	defer cancel()
+
 	reqSlp := backupdr.SlpRest{
 		Name:            plan.Name.ValueString(),
 		Description:     plan.Description.ValueString(),
@@ -246,19 +289,21 @@ func (r *profileResource) Create(ctx context.Context, req resource.CreateRequest
 	}
 
 	// Create new slp
-	respObject, _, err := r.client.SLAProfileApi.CreateSlp(r.authCtx, &reqBody)
+	respObject, res, err := r.client.SLAProfileApi.CreateSlp(authCtx, &reqBody)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating SLA Profle",
-			"Could not create SLA Profile, unexpected error: "+err.Error(),
+		addConsoleError(&resp.Diagnostics,
+			"Error creating SLA Profile",
+			"Could not create SLA Profile.",
+			res, err, profileConsoleFields,
 		)
 		return
 	}
 
 	plan.Dedupasyncnode = types.StringValue(respObject.Dedupasyncnode)
-	plan.Remotenode = types.StringValue(respObject.Remotenode)
-	plan.Localnode = types.StringValue(respObject.Localnode)
-	plan.Performancepool = types.StringValue(respObject.Performancepool)
+	plan.Remotenode = optionalStringValue(plan.Remotenode, respObject.Remotenode)
+	plan.Cid = optionalStringValue(plan.Cid, respObject.Cid)
+	plan.Localnode = optionalStringValue(plan.Localnode, respObject.Localnode)
+	plan.Performancepool = optionalStringValue(plan.Performancepool, respObject.Performancepool, defaultPerformancePool)
 
 	// Map response body to schema and populate Computed attribute values
 	plan.ID = types.StringValue(respObject.Id)
@@ -298,6 +343,10 @@ func (r *profileResource) Create(ctx context.Context, req resource.CreateRequest
 
 // Read resource information.
 func (r *profileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if unknownProviderConfig(ctx, r.client) {
+		return
+	}
+
 	// Get current state
 	var state profileResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -306,12 +355,26 @@ func (r *profileResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, authCtx, cancel := withTimeout(ctx, r.authCtx, readTimeout)
+	defer cancel()
+
 	// Get refreshed values
-	respObject, _, err := r.client.SLAProfileApi.GetSlp(r.authCtx, state.ID.ValueString())
+	respObject, res, err := r.client.SLAProfileApi.GetSlp(authCtx, state.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
+		if isNotFound(res, err) {
+			tflog.Warn(ctx, "SLA Profile no longer exists, removing it from state", map[string]any{"id": state.ID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addConsoleError(&resp.Diagnostics,
 			"Error Reading SLA Profile",
-			"Could not read SLA Profile with ID "+state.ID.ValueString()+": "+err.Error(),
+			"Could not read SLA Profile with ID "+state.ID.ValueString()+".",
+			res, err, nil,
 		)
 		return
 	}
@@ -322,6 +385,16 @@ func (r *profileResource) Read(ctx context.Context, req resource.ReadRequest, re
 	state.Syncdate = types.Int64Value(respObject.Syncdate)
 	state.Modifydate = types.Int64Value(respObject.Modifydate)
 	state.Stale = types.BoolValue(respObject.Stale)
+	state.Name = types.StringValue(respObject.Name)
+	state.Description = optionalStringValue(state.Description, respObject.Description)
+	state.Cid = optionalStringValue(state.Cid, respObject.Cid)
+	state.Performancepool = optionalStringValue(state.Performancepool, respObject.Performancepool, defaultPerformancePool)
+	state.Localnode = optionalStringValue(state.Localnode, respObject.Localnode)
+	state.Remotenode = optionalStringValue(state.Remotenode, respObject.Remotenode)
+	state.Dedupasyncnode = types.StringValue(respObject.Dedupasyncnode)
+	state.Clusterid = types.StringValue(respObject.Clusterid)
+	state.Srcid = types.StringValue(respObject.Srcid)
+	state.Createdate = types.Int64Value(respObject.Createdate)
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
@@ -332,6 +405,10 @@ func (r *profileResource) Read(ctx context.Context, req resource.ReadRequest, re
 }
 
 func (r *profileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !checkProviderConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	// Retrieve values from plan
 	var plan profileResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -340,6 +417,14 @@ func (r *profileResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, authCtx, cancel := withTimeout(ctx, r.authCtx, updateTimeout)
+	defer cancel()
+
 	reqSlp := backupdr.SlpRest{
 		Name:            plan.Name.ValueString(),
 		Description:     plan.Description.ValueString(),
@@ -395,11 +480,12 @@ func (r *profileResource) Update(ctx context.Context, req resource.UpdateRequest
 	}
 
 	// Update existing order
-	respObject, res, err := r.client.SLAProfileApi.UpdateSlp(r.authCtx, plan.ID.ValueString(), &reqBody)
+	respObject, res, err := r.client.SLAProfileApi.UpdateSlp(authCtx, plan.ID.ValueString(), &reqBody)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addConsoleError(&resp.Diagnostics,
 			"Error Updating SLA Profile",
-			"An unexpected error occurred when updating the BackupDR SLA Profile, unexpected error: "+err.Error(),
+			"Could not update SLA Profile with ID "+plan.ID.ValueString()+".",
+			res, err, profileConsoleFields,
 		)
 		return
 	}
@@ -415,9 +501,10 @@ func (r *profileResource) Update(ctx context.Context, req resource.UpdateRequest
 
 	// Update resource state with updated items and timestamp
 	plan.Dedupasyncnode = types.StringValue(respObject.Dedupasyncnode)
-	plan.Remotenode = types.StringValue(respObject.Remotenode)
-	plan.Localnode = types.StringValue(respObject.Localnode)
-	plan.Performancepool = types.StringValue(respObject.Performancepool)
+	plan.Remotenode = optionalStringValue(plan.Remotenode, respObject.Remotenode)
+	plan.Cid = optionalStringValue(plan.Cid, respObject.Cid)
+	plan.Localnode = optionalStringValue(plan.Localnode, respObject.Localnode)
+	plan.Performancepool = optionalStringValue(plan.Performancepool, respObject.Performancepool, defaultPerformancePool)
 
 	// Map response body to schema and populate Computed attribute values
 	plan.ID = types.StringValue(respObject.Id)
@@ -429,8 +516,8 @@ func (r *profileResource) Update(ctx context.Context, req resource.UpdateRequest
 	plan.Modifydate = types.Int64Value(respObject.Modifydate)
 	plan.Syncdate = types.Int64Value(respObject.Syncdate)
 
-	if respObject.Vaultpool != nil || plan.Vaultpool != nil {
-		if plan.Vaultpool.ID.ValueString() != "0" {
+	if plan.Vaultpool != nil {
+		if respObject.Vaultpool != nil && plan.Vaultpool.ID.ValueString() != "0" {
 			plan.Vaultpool.Name = types.StringValue(respObject.Vaultpool.Name)
 			plan.Vaultpool.Href = types.StringValue(respObject.Vaultpool.Href)
 		} else {
@@ -439,8 +526,8 @@ func (r *profileResource) Update(ctx context.Context, req resource.UpdateRequest
 		}
 	}
 
-	if respObject.Vaultpool2 != nil || plan.Vaultpool2 != nil {
-		if plan.Vaultpool2.ID.ValueString() != "0" {
+	if plan.Vaultpool2 != nil {
+		if respObject.Vaultpool2 != nil && plan.Vaultpool2.ID.ValueString() != "0" {
 			plan.Vaultpool2.Name = types.StringValue(respObject.Vaultpool2.Name)
 			plan.Vaultpool2.Href = types.StringValue(respObject.Vaultpool2.Href)
 		} else {
@@ -449,8 +536,8 @@ func (r *profileResource) Update(ctx context.Context, req resource.UpdateRequest
 		}
 	}
 
-	if respObject.Vaultpool3 != nil || plan.Vaultpool3 != nil {
-		if plan.Vaultpool3.ID.ValueString() != "0" {
+	if plan.Vaultpool3 != nil {
+		if respObject.Vaultpool3 != nil && plan.Vaultpool3.ID.ValueString() != "0" {
 			plan.Vaultpool3.Name = types.StringValue(respObject.Vaultpool3.Name)
 			plan.Vaultpool3.Href = types.StringValue(respObject.Vaultpool3.Href)
 		} else {
@@ -459,8 +546,8 @@ func (r *profileResource) Update(ctx context.Context, req resource.UpdateRequest
 		}
 	}
 
-	if respObject.Vaultpool4 != nil || plan.Vaultpool4 != nil {
-		if plan.Vaultpool4.ID.ValueString() != "0" {
+	if plan.Vaultpool4 != nil {
+		if respObject.Vaultpool4 != nil && plan.Vaultpool4.ID.ValueString() != "0" {
 			plan.Vaultpool4.Name = types.StringValue(respObject.Vaultpool4.Name)
 			plan.Vaultpool4.Href = types.StringValue(respObject.Vaultpool4.Href)
 		} else {
@@ -477,6 +564,10 @@ func (r *profileResource) Update(ctx context.Context, req resource.UpdateRequest
 }
 
 func (r *profileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !checkProviderConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	// Retrieve values from state
 	var state profileResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -485,18 +576,31 @@ func (r *profileResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, authCtx, cancel := withTimeout(ctx, r.authCtx, deleteTimeout)
+	defer cancel()
+
 	// Delete existing SLA profile
-	_, err := r.client.SLAProfileApi.DeleteSlp(r.authCtx, state.ID.ValueString())
+	res, err := r.client.SLAProfileApi.DeleteSlp(authCtx, state.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addConsoleError(&resp.Diagnostics,
 			"Error Deleting SLA Profile",
-			"Could not delete slp, unexpected error: "+err.Error(),
+			"Could not delete SLA Profile with ID "+state.ID.ValueString()+".",
+			res, err, nil,
 		)
 		return
 	}
 }
 
 func (r *profileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if !checkProviderConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	// Retrieve import ID and save to id attribute
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
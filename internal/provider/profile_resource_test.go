@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccProfileResourceConfig(console *fakeConsole, description string) string {
+	return testAccProviderConfig(console) + fmt.Sprintf(`
+resource "backupdr_profile" "test" {
+  name            = "test-profile"
+  description     = %q
+  cid             = "4123"
+  performancepool = "act_per_pool000"
+  localnode       = "backup-appliance-1"
+}
+`, description)
+}
+
+func TestAccProfileResource(t *testing.T) {
+	console := newFakeConsole(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             console.CheckDestroyed("slp"),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProfileResourceConfig(console, "first"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("backupdr_profile.test", "name", "test-profile"),
+					resource.TestCheckResourceAttr("backupdr_profile.test", "description", "first"),
+					resource.TestCheckResourceAttrSet("backupdr_profile.test", "id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "backupdr_profile.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccProfileResourceConfig(console, "second"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("backupdr_profile.test", "description", "second"),
+					console.CheckField("slp", "backupdr_profile.test", "description", "second"),
+				),
+			},
+			// Drift testing
+			{
+				PreConfig: func() {
+					id := console.Find("slp", "name", "test-profile")
+					console.Update("slp", id, map[string]any{"description": "changed in console"})
+				},
+				Config:             testAccProfileResourceConfig(console, "second"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Removed from state when deleted in the console
+			{
+				PreConfig: func() {
+					console.Delete("slp", console.Find("slp", "name", "test-profile"))
+				},
+				Config:             testAccProfileResourceConfig(console, "second"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestProfileResource_readKeepsConsoleDefaultsNull(t *testing.T) {
+	console := newFakeConsole(t)
+	id := console.Seed("slp", map[string]any{"name": "minimal"})
+
+	state := readResource(t, &profileResource{client: console.Client(), authCtx: context.Background()}, map[string]any{
+		"id":   types.StringValue(id),
+		"name": types.StringValue("minimal"),
+	})
+
+	var got profileResourceModel
+	if diags := state.Get(context.Background(), &got); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if !got.Performancepool.IsNull() {
+		t.Errorf("got performancepool %s, want null", got.Performancepool)
+	}
+}
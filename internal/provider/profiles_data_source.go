@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 
+	"github.com/antihax/optional"
 	backupdr "github.com/umeshkumhar/backupdr-client"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -25,7 +26,10 @@ type profileAllDataSource struct {
 // tf go model
 type allProfileResourceModel struct {
 	// Count types.Int64        `tfsdk:"count"`
-	Items []profileResourceModel `tfsdk:"items"`
+	Limit  types.Int64              `tfsdk:"limit"`
+	Sort   types.String             `tfsdk:"sort"`
+	Filter types.String             `tfsdk:"filter"`
+	Items  []profileDataSourceModel `tfsdk:"items"`
 }
 
 // NewProfileAllDataSource - Datasource for SLA Profile
@@ -50,7 +54,7 @@ func (d *profileAllDataSource) Metadata(ctx context.Context, req datasource.Meta
 func (d *profileAllDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "This data source can be used to read information about all backup profiles. It displays the resource profile IDs as shown in the **Management console** > **Backup Plans** > **Profiles** page.",
-		Attributes: map[string]schema.Attribute{
+		Attributes: listAttributes("profiles", map[string]schema.Attribute{
 			"items": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -187,36 +191,52 @@ func (d *profileAllDataSource) Schema(ctx context.Context, req datasource.Schema
 					},
 				},
 			},
-		},
+		}),
 	}
 }
 
 func (d *profileAllDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 
+	if !checkProviderConfigured(d.client, &resp.Diagnostics) {
+		return
+	}
+
 	var state allProfileResourceModel
 	// Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
-
-	slp, res, err := d.client.SLAProfileApi.ListSlps(d.authCtx, nil)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Read BackupDR SLA Profiles",
-			err.Error(),
-		)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	opts, ok := newListOptions(state.Limit, state.Sort, state.Filter, &resp.Diagnostics)
+	if !ok {
 		return
 	}
 
-	if res.StatusCode != 200 {
-		resp.Diagnostics.AddError(
+	profiles, err := listAll(opts.Limit, func(limit, offset int64) ([]backupdr.SlpRest, int32, error) {
+		page, res, err := d.client.SLAProfileApi.ListSlps(d.authCtx, &backupdr.SLAProfileApiListSlpsOpts{
+			Sort:   optionalString(opts.Sort),
+			Filter: optionalString(opts.Filter),
+			Limit:  optional.NewInt64(limit),
+			Offset: optional.NewInt64(offset),
+		})
+		if err != nil {
+			return nil, 0, newRequestError(res, err)
+		}
+		return page.Items, page.Count, nil
+	}, nil)
+	if err != nil {
+		addConsoleError(&resp.Diagnostics,
 			"Unable to Read BackupDR SLA Profiles",
-			res.Status,
+			"Could not list SLA Profiles.",
+			nil, err, nil,
 		)
+		return
 	}
 
-	var slps = []profileResourceModel{}
+	var slps = []profileDataSourceModel{}
 	// Map response body to model
-	for _, v := range slp.Items {
-		slpState := profileResourceModel{
+	for _, v := range profiles {
+		slpState := profileDataSourceModel{
 			ID:              types.StringValue(v.Id),
 			Href:            types.StringValue(v.Href),
 			Name:            types.StringValue(v.Name),
@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccProfilesDataSource(t *testing.T) {
+	console := newFakeConsole(t)
+	console.Seed("slp", map[string]any{"name": "profile-1", "clusterid": "144292692833"})
+	console.Seed("slp", map[string]any{"name": "profile-2", "clusterid": "144292692834"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(console) + `
+data "backupdr_profiles" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backupdr_profiles.test", "items.#", "2"),
+					resource.TestCheckResourceAttr("data.backupdr_profiles.test", "items.0.name", "profile-1"),
+					resource.TestCheckResourceAttr("data.backupdr_profiles.test", "items.1.clusterid", "144292692834"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProfilesDataSource_pages(t *testing.T) {
+	defer func(size int64) { listPageSize = size }(listPageSize)
+	listPageSize = 1
+
+	console := newFakeConsole(t)
+	for _, name := range []string{"profile-1", "profile-2", "profile-3"} {
+		console.Seed("slp", map[string]any{"name": name})
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(console) + `
+data "backupdr_profiles" "all" {}
+
+data "backupdr_profiles" "limited" {
+  limit = 2
+}
+
+data "backupdr_profiles" "filtered" {
+  filter = "name:==profile-3"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backupdr_profiles.all", "items.#", "3"),
+					resource.TestCheckResourceAttr("data.backupdr_profiles.all", "items.2.name", "profile-3"),
+					resource.TestCheckResourceAttr("data.backupdr_profiles.limited", "items.#", "2"),
+					resource.TestCheckResourceAttr("data.backupdr_profiles.filtered", "items.#", "1"),
+					resource.TestCheckResourceAttr("data.backupdr_profiles.filtered", "items.0.name", "profile-3"),
+				),
+			},
+		},
+	})
+}
@@ -3,17 +3,21 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
+	"time"
 
 	backupdr "github.com/umeshkumhar/backupdr-client"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/oauth2"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -37,13 +41,38 @@ type backupdrProvider struct {
 	// testing.
 	version string
 	client  *backupdr.APIClient
-	authCtx context.Context
+	// clientConfig is the configuration of client, for the console
+	// requests it has no methods for.
+	clientConfig *backupdr.Configuration
+	authCtx      context.Context
+	session      *sessionManager
+	// applications is the application index shared by the application
+	// resources.
+	applications *applicationIndex
+	// secrets collects the values masked in the logs of this provider
+	// instance.
+	secrets *secrets
 }
 
 // backupdrProviderModel maps provider schema data to a Go type.
 type backupdrProviderModel struct {
-	Endpoint       types.String `tfsdk:"endpoint"`
-	GcpAccessToken types.String `tfsdk:"access_token"`
+	Endpoint                  types.String  `tfsdk:"endpoint"`
+	GcpAccessToken            types.String  `tfsdk:"access_token"`
+	Credentials               types.String  `tfsdk:"credentials"`
+	ImpersonateServiceAccount types.String  `tfsdk:"impersonate_service_account"`
+	MaxRetries                types.Int64   `tfsdk:"max_retries"`
+	RetryMinBackoff           types.String  `tfsdk:"retry_min_backoff"`
+	RetryMaxBackoff           types.String  `tfsdk:"retry_max_backoff"`
+	LogRequests               types.Bool    `tfsdk:"log_requests"`
+	DebugHTTPDumpDir          types.String  `tfsdk:"debug_http_dump_dir"`
+	CACertFile                types.String  `tfsdk:"ca_cert_file"`
+	CACertPEM                 types.String  `tfsdk:"ca_cert_pem"`
+	ProxyURL                  types.String  `tfsdk:"proxy_url"`
+	InsecureSkipVerify        types.Bool    `tfsdk:"insecure_skip_verify"`
+	RequestTimeout            types.String  `tfsdk:"request_timeout"`
+	Headers                   types.Map     `tfsdk:"headers"`
+	MaxConcurrentRequests     types.Int64   `tfsdk:"max_concurrent_requests"`
+	RequestsPerSecond         types.Float64 `tfsdk:"requests_per_second"`
 }
 
 // Metadata returns the provider type name.
@@ -61,12 +90,74 @@ func (p *backupdrProvider) Schema(ctx context.Context, _ provider.SchemaRequest,
 			"endpoint": schema.StringAttribute{
 				Required:            true,
 				Optional:            false,
-				MarkdownDescription: "Provide the management console API URL.",
+				MarkdownDescription: "Provide the management console API URL. It may depend on values only known at apply, such as the endpoint of a management console created in the same configuration. The provider does not support deferred actions, so until the configuration is known, resources keep their state while reading data sources and creating, updating, deleting or importing resources fail. Apply the management console first, for example with `-target`, then apply the rest.",
 			},
 			"access_token": schema.StringAttribute{
-				Required:            true,
-				Optional:            false,
-				MarkdownDescription: "Provide the gcp access_token.",
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Provide a static gcp access_token. It is not refreshed, so prefer `credentials` or Application Default Credentials for long runs. Conflicts with `credentials`.",
+			},
+			"credentials": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Provide the path or the JSON contents of a service account key file. When neither `access_token` nor `credentials` is set, Application Default Credentials are used.",
+			},
+			"impersonate_service_account": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Provide the email of a service account to impersonate. Tokens for it are minted from the configured credentials.",
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Provide how many times a management console call is retried after a transient failure (429, 502, 503, 504 or a connection error). Creates are only retried when the console certainly did not process them: after a 429 or a refused connection. Defaults to `3`; set `0` to disable retries.",
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Provide how many management console calls may be in flight at once, shared by every resource and data source of the provider. Calls beyond it wait for their turn. Defaults to no limit.",
+			},
+			"requests_per_second": schema.Float64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Provide how many management console calls may be sent per second, retries and logins included, shared by every resource and data source of the provider. Calls beyond it wait for their turn. Defaults to no limit.",
+			},
+			"retry_min_backoff": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Provide the initial wait between retries as a duration, for example `500ms`. It doubles on every attempt, and `0s` retries without waiting. Defaults to `1s`.",
+			},
+			"retry_max_backoff": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Provide the longest wait between retries as a duration, for example `1m`. A `Retry-After` header sent by the console takes precedence. Defaults to `30s`.",
+			},
+			"log_requests": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Log every management console request and response, including bodies, at `TRACE` level. Tokens, session IDs and passwords are masked. Defaults to `false`.",
+			},
+			"debug_http_dump_dir": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Provide a directory to write every management console request and response pair to, one file per call, for offline analysis. Tokens, session IDs and passwords are masked. The directory is created if needed.",
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Provide the path of a PEM file with CA certificates to trust in addition to the system ones, for a management console with a certificate from a private CA.",
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Provide PEM encoded CA certificates to trust in addition to the system ones. It can be combined with `ca_cert_file`.",
+			},
+			"proxy_url": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Provide the URL of the proxy to reach the management console through, for example `http://proxy.example.com:3128`. Defaults to the `HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY` environment variables.",
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Skip the verification of the management console TLS certificate. This exposes the credentials to anyone who can intercept the connection; prefer `ca_cert_file` or `ca_cert_pem`. Defaults to `false`.",
+			},
+			"request_timeout": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Provide the longest a management console call may take as a duration, for example `2m`, retries included. Defaults to no timeout.",
+			},
+			"headers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Provide extra headers to send with every management console request.",
 			},
 		},
 	}
@@ -84,45 +175,116 @@ func (p *backupdrProvider) Configure(ctx context.Context, req provider.Configure
 		return
 	}
 
-	if config.Endpoint.IsUnknown() {
+	// Default values to environment variables, but override
+	// with Terraform configuration value if set.
+
+	endpoint := os.Getenv("BACKUPDR_ENDPOINT")
+	accessToken := os.Getenv("BACKUPDR_ACCESS_TOKEN")
+	credentials := os.Getenv("BACKUPDR_CREDENTIALS")
+	impersonateServiceAccount := os.Getenv("BACKUPDR_IMPERSONATE_SERVICE_ACCOUNT")
+
+	if !config.Endpoint.IsNull() {
+		endpoint = config.Endpoint.ValueString()
+	}
+
+	if !config.GcpAccessToken.IsNull() {
+		accessToken = config.GcpAccessToken.ValueString()
+	}
+
+	if !config.Credentials.IsNull() {
+		credentials = config.Credentials.ValueString()
+	}
+
+	if !config.ImpersonateServiceAccount.IsNull() {
+		impersonateServiceAccount = config.ImpersonateServiceAccount.ValueString()
+	}
+
+	retry := defaultRetryPolicy()
+
+	if !config.MaxRetries.IsNull() {
+		if config.MaxRetries.ValueInt64() < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_retries"),
+				"Invalid BackupDR API Retry Configuration",
+				"The max_retries value must not be negative.",
+			)
+		}
+		retry.MaxRetries = int(config.MaxRetries.ValueInt64())
+	}
+
+	if config.MaxConcurrentRequests.ValueInt64() < 0 {
 		resp.Diagnostics.AddAttributeError(
-			path.Root("endpoint"),
-			"Unknown BackupDR API Endpoint",
-			"The provider cannot create the BackupDR API client as there is an unknown configuration value for the BackupDR API Endpoint. "+
-				"Either target apply the source of the value first, set the value statically in the configuration, or use the BACKUPDR_ENDPOINT environment variable.",
+			path.Root("max_concurrent_requests"),
+			"Invalid BackupDR API Request Limits",
+			"The max_concurrent_requests value must not be negative.",
 		)
 	}
 
-	if config.GcpAccessToken.IsUnknown() {
+	if config.RequestsPerSecond.ValueFloat64() < 0 {
 		resp.Diagnostics.AddAttributeError(
-			path.Root("user"),
-			"Unknown BackupDR API AccessToken",
-			"The provider cannot create the BackupDR API client as there is an unknown configuration value for the BackupDR API AcessToken. "+
-				"Either target apply the source of the value first, set the value statically in the configuration, or use the BACKUPDR_ACCESS_TOKEN environment variable.",
+			path.Root("requests_per_second"),
+			"Invalid BackupDR API Request Limits",
+			"The requests_per_second value must not be negative.",
 		)
 	}
 
-	if resp.Diagnostics.HasError() {
-		return
+	if !config.RetryMinBackoff.IsNull() && !config.RetryMinBackoff.IsUnknown() {
+		retry.MinBackoff = parseDurationAttribute(path.Root("retry_min_backoff"), config.RetryMinBackoff.ValueString(), &resp.Diagnostics)
 	}
 
-	// Default values to environment variables, but override
-	// with Terraform configuration value if set.
+	if !config.RetryMaxBackoff.IsNull() && !config.RetryMaxBackoff.IsUnknown() {
+		retry.MaxBackoff = parseDurationAttribute(path.Root("retry_max_backoff"), config.RetryMaxBackoff.ValueString(), &resp.Diagnostics)
+	}
 
-	endpoint := os.Getenv("BACKUPDR_ENDPOINT")
-	accessToken := os.Getenv("BACKUPDR_ACCESS_TOKEN")
+	dumpDir := config.DebugHTTPDumpDir.ValueString()
+	if dumpDir != "" {
+		if err := os.MkdirAll(dumpDir, 0o700); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("debug_http_dump_dir"),
+				"Invalid BackupDR API Debug Configuration",
+				"Could not create the debug_http_dump_dir directory: "+err.Error(),
+			)
+		}
+	}
 
-	if !config.Endpoint.IsNull() {
-		endpoint = config.Endpoint.ValueString()
+	var requestTimeout time.Duration
+	if !config.RequestTimeout.IsNull() && !config.RequestTimeout.IsUnknown() {
+		requestTimeout = parseDurationAttribute(path.Root("request_timeout"), config.RequestTimeout.ValueString(), &resp.Diagnostics)
 	}
 
-	if !config.GcpAccessToken.IsNull() {
-		accessToken = config.GcpAccessToken.ValueString()
+	var headers map[string]string
+	if !config.Headers.IsNull() && !config.Headers.IsUnknown() {
+		resp.Diagnostics.Append(config.Headers.ElementsAs(ctx, &headers, false)...)
+	}
+
+	baseTransport := newBaseTransport(transportSettings{
+		CACertFile:         config.CACertFile.ValueString(),
+		CACertPEM:          config.CACertPEM.ValueString(),
+		ProxyURL:           config.ProxyURL.ValueString(),
+		InsecureSkipVerify: config.InsecureSkipVerify.ValueBool(),
+	}, &resp.Diagnostics)
+
+	if config.InsecureSkipVerify.ValueBool() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("insecure_skip_verify"),
+			"BackupDR API TLS Verification Disabled",
+			"The provider does not verify the management console certificate, so anyone who can intercept the connection can read the access token and session. "+
+				"Trust the console certificate with ca_cert_file or ca_cert_pem instead.",
+		)
+		tflog.Warn(ctx, "TLS certificate verification of the management console is disabled")
+	}
+
+	if retry.MinBackoff > retry.MaxBackoff {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_min_backoff"),
+			"Invalid BackupDR API Retry Configuration",
+			"The retry_min_backoff value must not be greater than retry_max_backoff.",
+		)
 	}
 
 	// If any of the expected configurations are missing, return
 	// errors with provider-specific guidance.
-	if endpoint == "" {
+	if endpoint == "" && !config.Endpoint.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("endpoint"),
 			"Missing BackupDR API endpoint",
@@ -132,13 +294,12 @@ func (p *backupdrProvider) Configure(ctx context.Context, req provider.Configure
 		)
 	}
 
-	if accessToken == "" {
+	if accessToken != "" && credentials != "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("access_token"),
-			"Unknown BackupDR API AccessToken",
-			"The provider cannot create the BackupDR API client as there is a missing or empty value for the BackupDR API access_token. "+
-				"Set the access_token value in the configuration or use the BACKUPDR_ACCESS_TOKEN environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+			"Conflicting BackupDR API Credentials",
+			"The provider cannot create the BackupDR API client as both access_token and credentials are set. "+
+				"Set only one of them in the configuration or through the BACKUPDR_ACCESS_TOKEN and BACKUPDR_CREDENTIALS environment variables.",
 		)
 	}
 
@@ -146,56 +307,94 @@ func (p *backupdrProvider) Configure(ctx context.Context, req provider.Configure
 		return
 	}
 
-	ctx = tflog.SetField(ctx, "BACKUPDR_ENDPOINT", endpoint)
-	ctx = tflog.SetField(ctx, "BACKUPDR_ACCESS_TOKEN", accessToken)
-
-	tflog.Debug(ctx, "Creating BackupDR client")
+	// The endpoint or credentials may come from resources that do not exist
+	// yet. The attributes that are known are validated above, but the client
+	// is only created once every attribute is known. Until then, resources
+	// keep their prior state on refresh and fail to be changed, and data
+	// sources fail to be read.
+	if !req.Config.Raw.IsFullyKnown() {
+		tflog.Info(ctx, "BackupDR provider configuration is not known yet, deferring client creation")
+		resp.DataSourceData = p
+		resp.ResourceData = p
+		return
+	}
 
-	// define auth context with accessToken
-	authCtx := context.WithValue(context.Background(), backupdr.ContextAccessToken, accessToken)
+	// Register the secrets before anything is logged with them in scope.
+	p.secrets = &secrets{}
+	p.secrets.Add(accessToken, credentials)
+	ctx = p.secrets.Mask(ctx)
 
-	// define client configuration object
-	cfg := backupdr.NewConfiguration()
-	cfg.Host = endpoint
+	ctx = tflog.SetField(ctx, "BACKUPDR_ENDPOINT", endpoint)
 
-	// define backupdr client using configuration object
-	client := *backupdr.NewAPIClient(cfg)
-	sessionObj, res, err := client.UserSessionApi.Login(authCtx)
+	tflog.Debug(ctx, "Creating BackupDR client")
 
+	// The token source outlives this request, so it must not capture ctx.
+	// Tokens are fetched through the same proxy and CA settings.
+	tokenCtx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Transport: baseTransport})
+	tokenSource, err := newTokenSource(tokenCtx, tokenSourceConfig{
+		AccessToken:               accessToken,
+		Credentials:               credentials,
+		ImpersonateServiceAccount: impersonateServiceAccount,
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Unable to Create Session for BackupDR API Client",
-			"An unexpected error occurred when creating the BackupDR API client. "+
-				"If the error is not clear, please contact the provider developers.\n\n"+
+			"Unable to Create Token Source for BackupDR API Client",
+			"An unexpected error occurred when sourcing OAuth credentials for the BackupDR API client. "+
+				"Set access_token or credentials, or configure Application Default Credentials.\n\n"+
 				"BackupDR Client Error: "+err.Error(),
 		)
 		return
 	}
 
-	if res.StatusCode != 200 {
-		resp.Diagnostics.AddError(
-			"Unable to Create Session for BackupDR API Client",
-			"An unexpected error occurred when creating the BackupDR API client. "+
-				"If the error is not clear, please contact the provider developers.\n\n"+
-				"BackupDR Client Error: "+res.Status,
-		)
-	}
+	// define auth context with a refreshing token source
+	authCtx := context.WithValue(context.Background(), backupdr.ContextOAuth2, tokenSource)
 
-	if sessionObj.SessionId == "" {
-		resp.Diagnostics.AddError(
-			"Unable to Create SessionID for BackupDR API Client",
-			"An unexpected error occurred when creating the BackupDR API client. "+
-				"If the error is not clear, please contact the provider developers.\n\n"+
-				"BackupDR SessionID "+fmt.Sprint(sessionObj),
-		)
-		return
+	// The session manager re-logs in whenever the console rejects the
+	// session, so every API call goes through its transport.
+	session := newSessionManager(authCtx)
+	session.secrets = p.secrets
+
+	// Every call gets the query parameters carried by its context and a
+	// correlation ID shared by its retries, and each attempt waits for the
+	// request limits and is logged as it is sent.
+	cfg := backupdr.NewConfiguration()
+	cfg.Host = endpoint
+	for name, value := range headers {
+		cfg.AddDefaultHeader(name, value)
+	}
+	httpLogCtx := newHTTPLogContext(ctx)
+	cfg.HTTPClient = &http.Client{
+		Timeout: requestTimeout,
+		Transport: &queryTransport{
+			next: &correlationTransport{
+				next: &sessionTransport{
+					next: &retryTransport{
+						next: newLimitTransport(&loggingTransport{
+							next:      baseTransport,
+							secrets:   p.secrets,
+							logCtx:    httpLogCtx,
+							logBodies: config.LogRequests.ValueBool(),
+							dumpDir:   dumpDir,
+						}, int(config.MaxConcurrentRequests.ValueInt64()), config.RequestsPerSecond.ValueFloat64(), httpLogCtx),
+						policy: retry,
+					},
+					session: session,
+				},
+			},
+		},
 	}
 
-	p.authCtx = context.WithValue(authCtx, backupdr.ContextAPIKey, backupdr.APIKey{
-		Key:    sessionObj.SessionId,
-		Prefix: "Actifio",
-	})
-	p.client = &client
+	// define backupdr client using configuration object
+	client := backupdr.NewAPIClient(cfg)
+	session.client = client
+
+	// The session manager logs in on the first console call, so configuring
+	// the provider does not reach the console.
+	p.session = session
+	p.applications = newApplicationIndex()
+	p.authCtx = session.AuthContext()
+	p.client = client
+	p.clientConfig = cfg
 
 	// // Make the BackupDR client available during DataSource and Resource
 	// // type Configure methods.
@@ -217,6 +416,8 @@ func (p *backupdrProvider) DataSources(ctx context.Context) []func() datasource.
 		NewApplianceAllDataSource,
 		NewCloudCredentialDataSource,
 		NewCloudcredentialAllDataSource,
+		NewApplicationDataSource,
+		NewApplicationAllDataSource,
 	}
 }
 
@@ -232,3 +433,43 @@ func (p *backupdrProvider) Resources(ctx context.Context) []func() resource.Reso
 		NewApplicationComputeVMsResource,
 	}
 }
+
+// parseDurationAttribute parses a Go duration string from the provider
+// configuration, reporting an attribute error when it is malformed.
+func parseDurationAttribute(p path.Path, value string, diags *diag.Diagnostics) time.Duration {
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		diags.AddAttributeError(
+			p,
+			"Invalid Duration",
+			fmt.Sprintf("The value %q is not a valid non-negative duration such as \"500ms\", \"30s\" or \"2m\".", value),
+		)
+	}
+	return d
+}
+
+// unknownProviderConfig reports whether a resource was configured before the
+// provider configuration was known, so it has no client. Refreshing it then
+// keeps the prior state until the configuration is known.
+func unknownProviderConfig(ctx context.Context, client *backupdr.APIClient) bool {
+	if client != nil {
+		return false
+	}
+	tflog.Warn(ctx, "BackupDR provider configuration is not known yet, keeping the prior state")
+	return true
+}
+
+// checkProviderConfigured adds an error diagnostic and returns false when a
+// data source is read, or a resource is changed or imported, before the
+// provider configuration is known.
+func checkProviderConfigured(client *backupdr.APIClient, diags *diag.Diagnostics) bool {
+	if client != nil {
+		return true
+	}
+	diags.AddError(
+		"Unknown BackupDR Provider Configuration",
+		"The management console cannot be called as the BackupDR provider configuration depends on values that are not known yet, such as the endpoint of a management console created in the same run. "+
+			"Either target apply the source of those values first, or set them statically in the configuration.",
+	)
+	return false
+}
@@ -4,10 +4,21 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -15,11 +26,191 @@ import (
 // CLI command executed to create a provider server to which the CLI can
 // reattach.
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-	"scaffolding": providerserver.NewProtocol6WithError(New("test")()),
+	"backupdr": providerserver.NewProtocol6WithError(New("test")()),
 }
 
+// testAccPreCheck clears the provider environment variables so that only the
+// provider block returned by testAccProviderConfig configures the provider.
 func testAccPreCheck(t *testing.T) {
-	// You can add code here to run prior to any test case execution, for example assertions
-	// about the appropriate environment variables being set are common to see in a pre-check
-	// function.
+	for _, name := range []string{
+		"BACKUPDR_ENDPOINT",
+		"BACKUPDR_ACCESS_TOKEN",
+		"BACKUPDR_CREDENTIALS",
+		"BACKUPDR_IMPERSONATE_SERVICE_ACCOUNT",
+	} {
+		t.Setenv(name, "")
+	}
+}
+
+// testAccProviderConfig returns a provider block pointing to the fake
+// console. Retries are disabled so failures surface immediately.
+func testAccProviderConfig(console *fakeConsole) string {
+	return fmt.Sprintf(`
+provider "backupdr" {
+  endpoint     = %q
+  access_token = "test-token"
+  max_retries  = 0
+}
+`, console.URL())
+}
+
+// configureProvider configures a new provider with the given attribute values,
+// leaving the others null.
+func configureProvider(t *testing.T, values map[string]tftypes.Value) (*backupdrProvider, *provider.ConfigureResponse) {
+	t.Helper()
+	ctx := context.Background()
+
+	p := New("test")().(*backupdrProvider)
+	var schemaResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	attributes := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+	}
+	for name, value := range values {
+		attributes[name] = value
+	}
+
+	resp := &provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{Config: tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(objectType, attributes),
+	}}, resp)
+	return p, resp
+}
+
+// readResource refreshes r from a prior state with the given attribute
+// values, leaving the others null, and returns the refreshed state.
+func readResource(t *testing.T, r resource.Resource, values map[string]any) tfsdk.State {
+	t.Helper()
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	for name, value := range values {
+		if diags := state.SetAttribute(ctx, path.Root(name), value); diags.HasError() {
+			t.Fatalf("setting %s: %v", name, diags)
+		}
+	}
+
+	resp := &resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("got %v", resp.Diagnostics)
+	}
+	return resp.State
+}
+
+func TestConfigure_unknownEndpoint(t *testing.T) {
+	testAccPreCheck(t)
+
+	p, resp := configureProvider(t, map[string]tftypes.Value{
+		"endpoint":     tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"access_token": tftypes.NewValue(tftypes.String, "test-token"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if resp.ResourceData != p || resp.DataSourceData != p || p.client != nil {
+		t.Fatal("expected the provider data without a client")
+	}
+
+	var diags diag.Diagnostics
+	if checkProviderConfigured(p.client, &diags) || !diags.HasError() {
+		t.Error("expected data sources to report the unknown configuration")
+	}
+	if !unknownProviderConfig(context.Background(), p.client) {
+		t.Error("expected resources to keep their prior state")
+	}
+
+	// Changing a resource reports the unknown configuration instead of
+	// calling the missing client.
+	for _, newResource := range p.Resources(context.Background()) {
+		r := newResource()
+		r.(resource.ResourceWithConfigure).Configure(context.Background(), resource.ConfigureRequest{ProviderData: p}, &resource.ConfigureResponse{})
+
+		var createResp resource.CreateResponse
+		r.Create(context.Background(), resource.CreateRequest{}, &createResp)
+		var updateResp resource.UpdateResponse
+		r.Update(context.Background(), resource.UpdateRequest{}, &updateResp)
+		var deleteResp resource.DeleteResponse
+		r.Delete(context.Background(), resource.DeleteRequest{}, &deleteResp)
+		var importResp resource.ImportStateResponse
+		r.(resource.ResourceWithImportState).ImportState(context.Background(), resource.ImportStateRequest{ID: "1"}, &importResp)
+
+		for name, diags := range map[string]diag.Diagnostics{
+			"create": createResp.Diagnostics,
+			"update": updateResp.Diagnostics,
+			"delete": deleteResp.Diagnostics,
+			"import": importResp.Diagnostics,
+		} {
+			if !diags.HasError() {
+				t.Errorf("%T: expected %s to report the unknown configuration", r, name)
+			}
+		}
+	}
+}
+
+func TestConfigure_unknownEndpointValidatesKnownAttributes(t *testing.T) {
+	testAccPreCheck(t)
+
+	p, resp := configureProvider(t, map[string]tftypes.Value{
+		"endpoint":          tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"access_token":      tftypes.NewValue(tftypes.String, "test-token"),
+		"retry_min_backoff": tftypes.NewValue(tftypes.String, "soon"),
+	})
+	if !resp.Diagnostics.HasError() {
+		t.Error("expected the invalid retry_min_backoff to be reported")
+	}
+	if p.client != nil {
+		t.Error("expected no client")
+	}
+}
+
+func TestConfigure_lazyLogin(t *testing.T) {
+	testAccPreCheck(t)
+
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/actifio/session" {
+			fmt.Fprint(w, `{"session_id":"session-1"}`)
+			return
+		}
+		fmt.Fprint(w, `{"id":"1","name":"pool"}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	p, resp := configureProvider(t, map[string]tftypes.Value{
+		"endpoint":     tftypes.NewValue(tftypes.String, srv.URL),
+		"access_token": tftypes.NewValue(tftypes.String, "test-token"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	t.Cleanup(func() {
+		if err := p.session.Logout(context.Background()); err != nil {
+			t.Error(err)
+		}
+	})
+	if got := requests.Load(); got != 0 {
+		t.Fatalf("configuring the provider sent %d requests, want 0", got)
+	}
+
+	if _, _, err := p.client.DiskPoolApi.GetDiskPool(p.authCtx, "1"); err != nil {
+		t.Fatal(err)
+	}
+	if got := p.session.SessionID(); got != "session-1" {
+		t.Errorf("got session %q after the first call, want %q", got, "session-1")
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("got %d requests, want a login and the call", got)
+	}
 }
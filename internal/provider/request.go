@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	backupdr "github.com/umeshkumhar/backupdr-client"
+	"golang.org/x/oauth2"
+)
+
+// consoleRequest sends a JSON request to a console endpoint the client has
+// no method for, such as the options of a template, and decodes the response
+// into out when it is not nil. It authenticates like the client and goes
+// through the same transports, so it shares the session, retries and limits
+// of the provider. A failed request returns a *requestError.
+func consoleRequest(ctx context.Context, cfg *backupdr.Configuration, method, path string, body, out any) (*http.Response, error) {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, cfg.Host+cfg.BasePath+path, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if cfg.UserAgent != "" {
+		req.Header.Set("User-Agent", cfg.UserAgent)
+	}
+	if source, ok := ctx.Value(backupdr.ContextOAuth2).(oauth2.TokenSource); ok {
+		token, err := source.Token()
+		if err != nil {
+			return nil, err
+		}
+		token.SetAuthHeader(req)
+	}
+	if token, ok := ctx.Value(backupdr.ContextAccessToken).(string); ok {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	for name, value := range cfg.DefaultHeader {
+		req.Header.Add(name, value)
+	}
+
+	client := cfg.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return res, newRequestError(res, err)
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return res, newRequestError(res, err)
+	}
+	if res.StatusCode >= http.StatusMultipleChoices {
+		reqErr := newRequestError(res, errors.New(res.Status))
+		var payload consoleErrorPayload
+		if json.Unmarshal(data, &payload) == nil && (payload.ErrCode != 0 || payload.ErrMessage != "") {
+			reqErr.Console = &payload
+		}
+		return res, reqErr
+	}
+	if out != nil && len(data) > 0 {
+		if err := json.Unmarshal(data, out); err != nil {
+			return res, newRequestError(res, fmt.Errorf("unable to decode response: %w", err))
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	defaultMaxRetries      = 3
+	defaultRetryMinBackoff = time.Second
+	defaultRetryMaxBackoff = 30 * time.Second
+)
+
+// retryPolicy configures how transient management console failures are
+// retried.
+type retryPolicy struct {
+	MaxRetries int
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// defaultRetryPolicy returns the policy used when the provider configuration
+// does not override it.
+func defaultRetryPolicy() retryPolicy {
+	return retryPolicy{
+		MaxRetries: defaultMaxRetries,
+		MinBackoff: defaultRetryMinBackoff,
+		MaxBackoff: defaultRetryMaxBackoff,
+	}
+}
+
+// retryTransport retries requests that failed with a transient error, using
+// exponential backoff with jitter and honouring Retry-After.
+//
+// Non-idempotent requests (POST) are only retried when the console certainly
+// did not act on them: the connection was refused, or the console answered
+// 429 Too Many Requests. A 503 may come from a proxy after the console
+// already created the object, so it is only retried for idempotent requests.
+type retryTransport struct {
+	next   http.RoundTripper
+	policy retryPolicy
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	for attempt := 0; ; attempt++ {
+		try := req
+		if attempt > 0 {
+			var err error
+			if try, err = rewindRequest(req); err != nil {
+				return nil, err
+			}
+		}
+
+		res, err := t.next.RoundTrip(try)
+		if attempt >= t.policy.MaxRetries || ctx.Err() != nil || !shouldRetry(req.Method, res, err) {
+			return res, err
+		}
+
+		wait := t.backoff(attempt, res)
+		tflog.Warn(ctx, "Retrying BackupDR API request after transient failure", map[string]any{
+			"method":  req.Method,
+			"path":    req.URL.Path,
+			"attempt": attempt + 1,
+			"wait":    wait.String(),
+			"reason":  retryReason(res, err),
+		})
+		drainBody(res)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns how long to wait before the given retry attempt.
+func (t *retryTransport) backoff(attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if wait, ok := retryAfter(res.Header.Get("Retry-After")); ok {
+			return wait
+		}
+	}
+
+	if t.policy.MinBackoff <= 0 {
+		return 0
+	}
+	wait := t.policy.MinBackoff << attempt
+	// A non-positive wait means the shift overflowed.
+	if wait <= 0 || wait > t.policy.MaxBackoff {
+		wait = t.policy.MaxBackoff
+	}
+	// Full jitter on the upper half keeps parallel resources from retrying in
+	// lockstep.
+	half := int64(wait / 2)
+	if half > 0 {
+		wait = time.Duration(half + rand.Int63n(half+1))
+	}
+	return wait
+}
+
+// retryAfter parses a Retry-After header given either in seconds or as an
+// HTTP date.
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+// shouldRetry reports whether a request with the given method may be sent
+// again after this outcome.
+func shouldRetry(method string, res *http.Response, err error) bool {
+	idempotent := method != http.MethodPost && method != http.MethodPatch
+
+	if err != nil {
+		if errors.Is(err, syscall.ECONNREFUSED) {
+			return true
+		}
+		return idempotent && (isDialError(err) || isTransientNetworkError(err))
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return idempotent
+	}
+	return false
+}
+
+// isDialError reports whether err happened before the request was sent.
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// isTransientNetworkError reports whether err is a connection failure that
+// may succeed when retried.
+func isTransientNetworkError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNABORTED) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF)
+}
+
+func retryReason(res *http.Response, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return res.Status
+}
@@ -0,0 +1,206 @@
+package provider
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// stubTransport replays canned outcomes and records the bodies it was sent.
+type stubTransport struct {
+	outcomes []func() (*http.Response, error)
+	bodies   []string
+}
+
+func (s *stubTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body := ""
+	if req.Body != nil {
+		b, _ := io.ReadAll(req.Body)
+		body = string(b)
+	}
+	s.bodies = append(s.bodies, body)
+
+	next := s.outcomes[0]
+	if len(s.outcomes) > 1 {
+		s.outcomes = s.outcomes[1:]
+	}
+	return next()
+}
+
+func status(code int, header ...string) func() (*http.Response, error) {
+	return func() (*http.Response, error) {
+		res := &http.Response{
+			StatusCode: code,
+			Status:     http.StatusText(code),
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader("")),
+		}
+		for i := 0; i+1 < len(header); i += 2 {
+			res.Header.Set(header[i], header[i+1])
+		}
+		return res, nil
+	}
+}
+
+func failure(err error) func() (*http.Response, error) {
+	return func() (*http.Response, error) { return nil, err }
+}
+
+func testRetryTransport(stub *stubTransport, maxRetries int) *retryTransport {
+	return &retryTransport{
+		next: stub,
+		policy: retryPolicy{
+			MaxRetries: maxRetries,
+			MinBackoff: time.Millisecond,
+			MaxBackoff: 5 * time.Millisecond,
+		},
+	}
+}
+
+func TestRetryTransport(t *testing.T) {
+	dialErr := &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+	resetErr := &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+	dialTimeout := &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ETIMEDOUT}
+
+	cases := map[string]struct {
+		method     string
+		outcomes   []func() (*http.Response, error)
+		wantStatus int
+		wantErr    bool
+		wantCalls  int
+	}{
+		"get retried until success": {
+			method:     http.MethodGet,
+			outcomes:   []func() (*http.Response, error){status(503), status(502), status(200)},
+			wantStatus: 200,
+			wantCalls:  3,
+		},
+		"get gives up after max retries": {
+			method:     http.MethodGet,
+			outcomes:   []func() (*http.Response, error){status(429)},
+			wantStatus: 429,
+			wantCalls:  4,
+		},
+		"get retried after connection reset": {
+			method:     http.MethodGet,
+			outcomes:   []func() (*http.Response, error){failure(resetErr), status(200)},
+			wantStatus: 200,
+			wantCalls:  2,
+		},
+		"client errors are not retried": {
+			method:     http.MethodPut,
+			outcomes:   []func() (*http.Response, error){status(400)},
+			wantStatus: 400,
+			wantCalls:  1,
+		},
+		"post retried when throttled": {
+			method:     http.MethodPost,
+			outcomes:   []func() (*http.Response, error){status(429), status(200)},
+			wantStatus: 200,
+			wantCalls:  2,
+		},
+		"post retried when the connection was refused": {
+			method:     http.MethodPost,
+			outcomes:   []func() (*http.Response, error){failure(dialErr), status(200)},
+			wantStatus: 200,
+			wantCalls:  2,
+		},
+		"post not retried after bad gateway": {
+			method:     http.MethodPost,
+			outcomes:   []func() (*http.Response, error){status(502)},
+			wantStatus: 502,
+			wantCalls:  1,
+		},
+		"post not retried after service unavailable": {
+			method:     http.MethodPost,
+			outcomes:   []func() (*http.Response, error){status(503)},
+			wantStatus: 503,
+			wantCalls:  1,
+		},
+		"post not retried after dial timeout": {
+			method:    http.MethodPost,
+			outcomes:  []func() (*http.Response, error){failure(dialTimeout)},
+			wantErr:   true,
+			wantCalls: 1,
+		},
+		"post not retried after connection reset": {
+			method:    http.MethodPost,
+			outcomes:  []func() (*http.Response, error){failure(resetErr)},
+			wantErr:   true,
+			wantCalls: 1,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			stub := &stubTransport{outcomes: tc.outcomes}
+			req, err := http.NewRequest(tc.method, "https://console.example.com/actifio/slt", strings.NewReader(`{"name":"t"}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			res, err := testRetryTransport(stub, 3).RoundTrip(req)
+			if tc.wantErr != (err != nil) {
+				t.Fatalf("got error %v, want error: %t", err, tc.wantErr)
+			}
+			if err == nil && res.StatusCode != tc.wantStatus {
+				t.Errorf("got status %d, want %d", res.StatusCode, tc.wantStatus)
+			}
+			if len(stub.bodies) != tc.wantCalls {
+				t.Errorf("got %d calls, want %d", len(stub.bodies), tc.wantCalls)
+			}
+			for i, body := range stub.bodies {
+				if body != `{"name":"t"}` {
+					t.Errorf("call %d sent body %q", i, body)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryTransport_retryAfter(t *testing.T) {
+	stub := &stubTransport{outcomes: []func() (*http.Response, error){status(429, "Retry-After", "1"), status(200)}}
+	req, _ := http.NewRequest(http.MethodGet, "https://console.example.com/actifio/slt", nil)
+
+	start := time.Now()
+	res, err := testRetryTransport(stub, 3).RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != 200 {
+		t.Errorf("got status %d, want 200", res.StatusCode)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("Retry-After was not honoured, retried after %s", elapsed)
+	}
+}
+
+func TestRetryTransport_backoff(t *testing.T) {
+	tr := &retryTransport{policy: retryPolicy{MinBackoff: 0, MaxBackoff: 30 * time.Second}}
+	for attempt := 0; attempt < 3; attempt++ {
+		if wait := tr.backoff(attempt, nil); wait != 0 {
+			t.Errorf("attempt %d waited %s with a zero retry_min_backoff", attempt, wait)
+		}
+	}
+
+	tr.policy.MinBackoff = time.Second
+	if wait := tr.backoff(62, nil); wait < 15*time.Second || wait > 30*time.Second {
+		t.Errorf("got %s for an overflowing backoff, want it capped by retry_max_backoff", wait)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	if d, ok := retryAfter("3"); !ok || d != 3*time.Second {
+		t.Errorf("got %s, %t for seconds", d, ok)
+	}
+	if _, ok := retryAfter(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)); !ok {
+		t.Error("HTTP date was not parsed")
+	}
+	if _, ok := retryAfter("soon"); ok {
+		t.Error("invalid value was parsed")
+	}
+}
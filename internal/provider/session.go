@@ -0,0 +1,272 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	backupdr "github.com/umeshkumhar/backupdr-client"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// sessionHeader carries the management console session ID on every request.
+const sessionHeader = "backupdr-management-session"
+
+// sessionPrefix is prepended to the session ID in sessionHeader.
+const sessionPrefix = "Actifio"
+
+var (
+	// activeSessions tracks the sessions opened by every configured provider
+	// instance so they can be closed when the plugin shuts down.
+	activeSessions   = map[*sessionManager]struct{}{}
+	activeSessionsMu sync.Mutex
+)
+
+// sessionManager owns the management console login session shared by every
+// resource and data source of a provider instance. It logs in again when the
+// console rejects the current session.
+type sessionManager struct {
+	client *backupdr.APIClient
+	// tokenCtx carries the OAuth credentials used to log in.
+	tokenCtx context.Context
+
+	// secrets receives every session ID so it is masked in logs.
+	secrets *secrets
+
+	mu        sync.Mutex
+	sessionID string
+}
+
+// newSessionManager returns a session manager that is not yet logged in.
+// The client must be set before Login is called.
+func newSessionManager(tokenCtx context.Context) *sessionManager {
+	return &sessionManager{tokenCtx: tokenCtx}
+}
+
+// Login opens a new session and registers it for shutdown.
+func (m *sessionManager) Login(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.login(ctx)
+}
+
+// login opens a new session. The login request is bounded by the deadline and
+// cancellation of ctx, usually those of the request that needed the session,
+// but carries only the OAuth credentials, not the stale session.
+func (m *sessionManager) login(ctx context.Context) error {
+	sessionObj, res, err := m.client.UserSessionApi.Login(valuesContext{Context: ctx, values: m.tokenCtx, isolated: true})
+	if err != nil {
+		return newRequestError(res, err)
+	}
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("login failed: %s", res.Status)
+	}
+	if sessionObj.SessionId == "" {
+		return fmt.Errorf("login returned an empty session ID: %s", fmt.Sprint(sessionObj))
+	}
+
+	m.sessionID = sessionObj.SessionId
+	m.secrets.Add(m.sessionID)
+
+	activeSessionsMu.Lock()
+	activeSessions[m] = struct{}{}
+	activeSessionsMu.Unlock()
+
+	tflog.Debug(ctx, "Logged in to the BackupDR management console")
+	return nil
+}
+
+// ensureSession opens a session unless one is already open and returns its
+// ID. The provider does not log in when it is configured, only once a
+// resource or data source first calls the console.
+func (m *sessionManager) ensureSession(ctx context.Context) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.sessionID == "" {
+		if err := m.login(ctx); err != nil {
+			return "", err
+		}
+	}
+	return m.sessionID, nil
+}
+
+// SessionID returns the current session ID.
+func (m *sessionManager) SessionID() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.sessionID
+}
+
+// relogin opens a new session unless another caller already replaced the
+// stale one, and returns the session ID to retry with.
+func (m *sessionManager) relogin(ctx context.Context, stale string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.sessionID != stale {
+		return m.sessionID, nil
+	}
+
+	tflog.Info(ctx, "BackupDR management console session rejected, logging in again")
+	if err := m.login(ctx); err != nil {
+		return "", err
+	}
+	return m.sessionID, nil
+}
+
+// Logout closes the current session, if any.
+func (m *sessionManager) Logout(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	activeSessionsMu.Lock()
+	delete(activeSessions, m)
+	activeSessionsMu.Unlock()
+
+	if m.sessionID == "" {
+		return nil
+	}
+
+	_, err := m.client.UserSessionApi.Logout(m.authContext())
+	m.sessionID = ""
+	return err
+}
+
+// authContext returns the token context with the current session attached.
+// Callers must hold m.mu.
+func (m *sessionManager) authContext() context.Context {
+	return context.WithValue(m.tokenCtx, backupdr.ContextAPIKey, backupdr.APIKey{
+		Key:    m.sessionID,
+		Prefix: sessionPrefix,
+	})
+}
+
+// AuthContext returns a context carrying both the OAuth credentials and the
+// current session.
+func (m *sessionManager) AuthContext() context.Context {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.authContext()
+}
+
+// CloseSessions logs out every session opened by this plugin process. It is
+// called once the provider server has stopped.
+func CloseSessions(ctx context.Context) {
+	activeSessionsMu.Lock()
+	sessions := make([]*sessionManager, 0, len(activeSessions))
+	for m := range activeSessions {
+		sessions = append(sessions, m)
+	}
+	activeSessionsMu.Unlock()
+
+	for _, m := range sessions {
+		if err := m.Logout(ctx); err != nil {
+			tflog.Warn(ctx, "Unable to log out of the BackupDR management console: "+err.Error())
+		}
+	}
+}
+
+// sessionTransport attaches the current session to every request, logging in
+// first if needed, and, when the console answers 401 Unauthorized, logs in
+// again and retries the request once.
+type sessionTransport struct {
+	next    http.RoundTripper
+	session *sessionManager
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *sessionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if isSessionRequest(req) {
+		return t.next.RoundTrip(req)
+	}
+
+	sessionID, err := t.session.ensureSession(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	res, err := t.next.RoundTrip(withSession(req, sessionID))
+	if err != nil || res.StatusCode != http.StatusUnauthorized {
+		return res, err
+	}
+
+	// The rejected response holds a request slot of limitTransport until its
+	// body is closed, and logging in again needs one.
+	bufferBody(res)
+
+	retry, err := rewindRequest(req)
+	if err != nil {
+		// The body cannot be replayed, so surface the original failure.
+		return res, nil
+	}
+
+	newSessionID, err := t.session.relogin(req.Context(), sessionID)
+	if err != nil {
+		tflog.Warn(req.Context(), "Unable to log in to the BackupDR management console again: "+err.Error())
+		return res, nil
+	}
+
+	return t.next.RoundTrip(withSession(retry, newSessionID))
+}
+
+// isSessionRequest reports whether req opens or closes a session itself.
+func isSessionRequest(req *http.Request) bool {
+	return strings.HasSuffix(req.URL.Path, "/session") || strings.HasSuffix(req.URL.Path, "/session/current")
+}
+
+// withSession returns a copy of req carrying the given session ID.
+func withSession(req *http.Request, sessionID string) *http.Request {
+	if sessionID == "" {
+		return req
+	}
+	out := req.Clone(req.Context())
+	out.Header.Set(sessionHeader, sessionPrefix+" "+sessionID)
+	return out
+}
+
+// rewindRequest returns a copy of req with a fresh body so it can be sent
+// again.
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	out := req.Clone(req.Context())
+	if req.Body == nil || req.Body == http.NoBody {
+		return out, nil
+	}
+	if req.GetBody == nil {
+		return nil, fmt.Errorf("request body of %s %s cannot be replayed", req.Method, req.URL.Path)
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	out.Body = body
+	return out, nil
+}
+
+// drainBody discards and closes a response body that will not be returned to
+// the caller so its connection can be reused.
+func drainBody(res *http.Response) {
+	if res == nil || res.Body == nil {
+		return
+	}
+	_, _ = io.Copy(io.Discard, res.Body)
+	res.Body.Close()
+}
+
+// bufferBody reads and closes the body of res, releasing its connection, and
+// replaces it with the bytes read so it can still be returned to the caller.
+func bufferBody(res *http.Response) {
+	if res.Body == nil {
+		return
+	}
+	data, _ := io.ReadAll(res.Body)
+	res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewReader(data))
+}
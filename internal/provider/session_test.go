@@ -0,0 +1,234 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	backupdr "github.com/umeshkumhar/backupdr-client"
+)
+
+// fakeSessionConsole issues numbered sessions and only accepts the latest.
+type fakeSessionConsole struct {
+	mu      sync.Mutex
+	logins  int
+	logouts int
+	valid   string
+}
+
+func (c *fakeSessionConsole) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/actifio/session":
+		c.logins++
+		c.valid = fmt.Sprintf("session-%d", c.logins)
+		fmt.Fprintf(w, `{"session_id":%q}`, c.valid)
+	case r.Method == http.MethodDelete && r.URL.Path == "/actifio/session/current":
+		if r.Header.Get(sessionHeader) == sessionPrefix+" "+c.valid {
+			c.logouts++
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case r.Header.Get(sessionHeader) != sessionPrefix+" "+c.valid:
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"err_code":10011,"err_message":"session expired"}`)
+	default:
+		fmt.Fprint(w, `{"id":"1","name":"pool"}`)
+	}
+}
+
+func (c *fakeSessionConsole) expire() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.valid = "expired"
+}
+
+func newTestSession(t *testing.T, console http.Handler) (*sessionManager, *backupdr.APIClient) {
+	return newTestSessionOver(t, console, http.DefaultTransport)
+}
+
+// newTestSessionOver is newTestSession with the session transport sending
+// its requests through next.
+func newTestSessionOver(t *testing.T, console http.Handler, next http.RoundTripper) (*sessionManager, *backupdr.APIClient) {
+	t.Helper()
+	srv := httptest.NewServer(console)
+	t.Cleanup(srv.Close)
+
+	session := newSessionManager(context.WithValue(context.Background(), backupdr.ContextAccessToken, "token"))
+	cfg := backupdr.NewConfiguration()
+	cfg.Host = srv.URL
+	cfg.HTTPClient = &http.Client{Transport: &sessionTransport{next: next, session: session}}
+	client := backupdr.NewAPIClient(cfg)
+	session.client = client
+
+	if err := session.Login(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return session, client
+}
+
+func TestSessionTransport_reloginOnUnauthorized(t *testing.T) {
+	console := &fakeSessionConsole{}
+	session, client := newTestSession(t, console)
+	authCtx := session.AuthContext()
+
+	console.expire()
+
+	pool, _, err := client.DiskPoolApi.GetDiskPool(authCtx, "1")
+	if err != nil {
+		t.Fatalf("expected the request to succeed after re-login, got %s", err)
+	}
+	if pool.Name != "pool" {
+		t.Errorf("got pool %q, want %q", pool.Name, "pool")
+	}
+	if console.logins != 2 {
+		t.Errorf("got %d logins, want 2", console.logins)
+	}
+	if got := session.SessionID(); got != "session-2" {
+		t.Errorf("got session %q, want %q", got, "session-2")
+	}
+
+	// Requests built from the original context use the refreshed session.
+	if _, _, err := client.DiskPoolApi.GetDiskPool(authCtx, "1"); err != nil {
+		t.Fatal(err)
+	}
+	if console.logins != 2 {
+		t.Errorf("got %d logins after a valid request, want 2", console.logins)
+	}
+}
+
+func TestSessionTransport_concurrentReloginOnce(t *testing.T) {
+	console := &fakeSessionConsole{}
+	session, client := newTestSession(t, console)
+	authCtx := session.AuthContext()
+
+	console.expire()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := client.DiskPoolApi.GetDiskPool(authCtx, "1"); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if console.logins != 2 {
+		t.Errorf("got %d logins, want 2", console.logins)
+	}
+}
+
+func TestSessionTransport_reloginWithinConcurrencyLimit(t *testing.T) {
+	// The transports below the session, as the provider chains them, with a
+	// single request in flight at a time.
+	next := &retryTransport{
+		next:   newLimitTransport(http.DefaultTransport, 1, 0, context.Background()),
+		policy: retryPolicy{MaxRetries: 0},
+	}
+	console := &fakeSessionConsole{}
+	session, client := newTestSessionOver(t, console, next)
+
+	console.expire()
+
+	ctx, cancel := context.WithTimeout(session.AuthContext(), 5*time.Second)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := client.DiskPoolApi.GetDiskPool(ctx, "1"); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if console.logins != 2 {
+		t.Errorf("got %d logins, want 2", console.logins)
+	}
+}
+
+func TestSessionTransport_rejectedResponseReadable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/actifio/session" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"err_code":10011,"err_message":"session expired"}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	session := newSessionManager(context.WithValue(context.Background(), backupdr.ContextAccessToken, "token"))
+	session.sessionID = "expired"
+	cfg := backupdr.NewConfiguration()
+	cfg.Host = srv.URL
+	cfg.HTTPClient = &http.Client{Transport: &sessionTransport{next: newLimitTransport(http.DefaultTransport, 1, 0, context.Background()), session: session}}
+	client := backupdr.NewAPIClient(cfg)
+	session.client = client
+
+	// The login fails, so the rejected response is returned with its body.
+	_, res, err := client.DiskPoolApi.GetDiskPool(session.AuthContext(), "1")
+	if reqErr := newRequestError(res, err); reqErr.Console == nil || reqErr.Console.ErrCode != 10011 {
+		t.Errorf("got %v, want the console error of the rejected request", err)
+	}
+}
+
+func TestSessionManager_loginHonoursDeadline(t *testing.T) {
+	hung := make(chan struct{})
+	t.Cleanup(func() { close(hung) })
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-hung:
+		case <-r.Context().Done():
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	session := newSessionManager(context.WithValue(context.Background(), backupdr.ContextAccessToken, "token"))
+	cfg := backupdr.NewConfiguration()
+	cfg.Host = srv.URL
+	session.client = backupdr.NewAPIClient(cfg)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := session.ensureSession(ctx); err == nil {
+		t.Fatal("expected the hung login to fail")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("login returned after %s, want it bounded by the request deadline", elapsed)
+	}
+}
+
+func TestCloseSessions(t *testing.T) {
+	console := &fakeSessionConsole{}
+	session, _ := newTestSession(t, console)
+
+	CloseSessions(context.Background())
+
+	if console.logouts != 1 {
+		t.Errorf("got %d logouts, want 1", console.logouts)
+	}
+	if session.SessionID() != "" {
+		t.Errorf("session was not cleared after logout")
+	}
+
+	// Closing again is a no-op.
+	CloseSessions(context.Background())
+	if console.logouts != 1 {
+		t.Errorf("got %d logouts after a second close, want 1", console.logouts)
+	}
+}
@@ -2,12 +2,15 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 
+	"github.com/antihax/optional"
 	backupdr "github.com/umeshkumhar/backupdr-client"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -47,12 +50,14 @@ func (d *templateDataSource) Schema(ctx context.Context, _ datasource.SchemaRequ
 		MarkdownDescription: "This data source can be used to read information about a backup template. It displays the backup template ID as shown in the **Management console** > **Backup Plans** > **Templates** page.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "Provide the backup template ID.",
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Provide the backup template ID. Either `id` or `name` must be set.",
 			},
 			"name": schema.StringAttribute{
+				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "It displays the name of the backup template.",
+				MarkdownDescription: "Provide the name of the backup template to look it up instead of `id`.",
 			},
 			"href": schema.StringAttribute{
 				Computed:            true,
@@ -211,15 +216,31 @@ func (d *templateDataSource) Schema(ctx context.Context, _ datasource.SchemaRequ
 
 func (d *templateDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 
-	var state templateResourceModel
+	if !checkProviderConfigured(d.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state templateDataSourceModel
 	// Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if !checkLookupKeys(state.ID, state.Name, "name", &resp.Diagnostics) {
+		return
+	}
+
+	templateID := state.ID.ValueString()
+	if templateID == "" {
+		var ok bool
+		if templateID, ok = d.lookupID(state, &resp.Diagnostics); !ok {
+			return
+		}
+	}
 
-	slt, res, err := d.client.SLATemplateApi.GetSlt(d.authCtx, state.ID.ValueString())
+	slt, res, err := d.client.SLATemplateApi.GetSlt(d.authCtx, templateID)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addConsoleError(&resp.Diagnostics,
 			"Unable to Read BackupDR SLATemplate",
-			err.Error(),
+			"Could not read SLT Template with ID "+templateID+".",
+			res, err, nil,
 		)
 		return
 	}
@@ -233,7 +254,7 @@ func (d *templateDataSource) Read(ctx context.Context, req datasource.ReadReques
 	}
 
 	// Map response body to model
-	sltState := templateResourceModel{
+	sltState := templateDataSourceModel{
 		ID:          types.StringValue(slt.Id),
 		Href:        types.StringValue(slt.Href),
 		Name:        types.StringValue(slt.Name),
@@ -245,12 +266,13 @@ func (d *templateDataSource) Read(ctx context.Context, req datasource.ReadReques
 	}
 
 	// Fetch Policies for the given SLT
-	sltID, _ := strconv.Atoi(state.ID.ValueString())
+	sltID, _ := strconv.Atoi(slt.Id)
 	sltPolicies, res, err := d.client.SLATemplateApi.ListPolicies(d.authCtx, int64(sltID))
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addConsoleError(&resp.Diagnostics,
 			"Unable to Read BackupDR SLATemplate Policies",
-			err.Error(),
+			"Could not read policies of SLT Template "+slt.Id+".",
+			res, err, nil,
 		)
 		return
 	}
@@ -310,3 +332,28 @@ func (d *templateDataSource) Read(ctx context.Context, req datasource.ReadReques
 	}
 
 }
+
+// lookupID resolves the backup template ID from its name.
+func (d *templateDataSource) lookupID(state templateDataSourceModel, diags *diag.Diagnostics) (string, bool) {
+	name := state.Name.ValueString()
+	filter := backupdr.SLATemplateApiListSltsOpts{
+		Filter: optional.NewString("name:==" + name),
+	}
+	slts, res, err := d.client.SLATemplateApi.ListSlts(d.authCtx, &filter)
+	if err != nil {
+		addConsoleError(diags,
+			"Unable to Read BackupDR SLATemplate",
+			"Could not list SLT Templates named "+name+".",
+			res, err, nil,
+		)
+		return "", false
+	}
+
+	var candidates []lookupCandidate
+	for _, slt := range slts.Items {
+		if slt.Name == name {
+			candidates = append(candidates, lookupCandidate{ID: slt.Id, Name: slt.Name})
+		}
+	}
+	return resolveLookupID("Backup Template", fmt.Sprintf("name %q", name), candidates, diags)
+}
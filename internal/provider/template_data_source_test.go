@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccTemplateDataSource(t *testing.T) {
+	console := newFakeConsole(t)
+	templateID := console.Seed("slt", map[string]any{
+		"name":        "test-template",
+		"description": "daily snapshots",
+	})
+	console.Seed("slt/"+templateID+"/policy", map[string]any{
+		"name":      "daily-snapshot",
+		"op":        "snap",
+		"retention": "14",
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(console) + `
+data "backupdr_template" "by_id" {
+  id = "` + templateID + `"
+}
+
+data "backupdr_template" "by_name" {
+  name = "test-template"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backupdr_template.by_id", "description", "daily snapshots"),
+					resource.TestCheckResourceAttr("data.backupdr_template.by_id", "policies.#", "1"),
+					resource.TestCheckResourceAttr("data.backupdr_template.by_id", "policies.0.name", "daily-snapshot"),
+					resource.TestCheckResourceAttr("data.backupdr_template.by_name", "id", templateID),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,257 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/antihax/optional"
+	backupdr "github.com/umeshkumhar/backupdr-client"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// optionsAttribute returns the options attribute of a template or of one of
+// its policies, described as belonging to owner.
+func optionsAttribute(owner string) schema.MapAttribute {
+	return schema.MapAttribute{
+		ElementType:         types.StringType,
+		Optional:            true,
+		MarkdownDescription: "Provide the advanced options of the " + owner + ", such as consistency, job timeout or VM snapshot settings, as option names and values as the management console lists them. Options set in the console but missing here are removed. Leave unset to keep the options set in the console unmanaged.",
+	}
+}
+
+// optionSet is the console API of a set of advanced options: the options of
+// a template or of one of its policies.
+type optionSet struct {
+	list   func() ([]backupdr.AdvancedOptionRest, *http.Response, error)
+	create func(option backupdr.AdvancedOptionRest) (*http.Response, error)
+	update func(id string, option backupdr.AdvancedOptionRest) (*http.Response, error)
+	delete func(id string) (*http.Response, error)
+}
+
+// templateOptionSet returns the options of a template, found at the
+// option_href of the template. The client has no methods for them, so they
+// are requested directly.
+func templateOptionSet(ctx context.Context, cfg *backupdr.Configuration, sltID, optionHref string) optionSet {
+	path := templateOptionsPath(cfg, sltID, optionHref)
+	return optionSet{
+		list: func() ([]backupdr.AdvancedOptionRest, *http.Response, error) {
+			var options backupdr.ListAdvancedOptionRest
+			res, err := consoleRequest(ctx, cfg, http.MethodGet, path, nil, &options)
+			return options.Items, res, err
+		},
+		create: func(option backupdr.AdvancedOptionRest) (*http.Response, error) {
+			return consoleRequest(ctx, cfg, http.MethodPost, path, option, nil)
+		},
+		update: func(id string, option backupdr.AdvancedOptionRest) (*http.Response, error) {
+			return consoleRequest(ctx, cfg, http.MethodPut, path+"/"+id, option, nil)
+		},
+		delete: func(id string) (*http.Response, error) {
+			return consoleRequest(ctx, cfg, http.MethodDelete, path+"/"+id, nil, nil)
+		},
+	}
+}
+
+// templateOptionsPath returns the path of the options of a template below the
+// base path of cfg. Only the path of optionHref is used, since the console
+// may name itself by another host than the endpoint it is reached at. The
+// path is built from the template ID when the console returns no href.
+func templateOptionsPath(cfg *backupdr.Configuration, sltID, optionHref string) string {
+	if u, err := url.Parse(optionHref); err == nil && strings.HasPrefix(u.Path, cfg.BasePath+"/") {
+		return strings.TrimPrefix(u.Path, cfg.BasePath)
+	}
+	return "/slt/" + sltID + "/option"
+}
+
+// policyOptionSet returns the options of a template policy.
+func policyOptionSet(ctx context.Context, client *backupdr.APIClient, sltID, policyID string) optionSet {
+	return optionSet{
+		list: func() ([]backupdr.AdvancedOptionRest, *http.Response, error) {
+			options, res, err := client.SLATemplateApi.ListOptionForPolicy(ctx, sltID, policyID)
+			return options.Items, res, err
+		},
+		create: func(option backupdr.AdvancedOptionRest) (*http.Response, error) {
+			_, res, err := client.SLATemplateApi.CreateOptionForPolicy(ctx, sltID, policyID, &backupdr.SLATemplateApiCreateOptionForPolicyOpts{
+				Body: optional.NewInterface(option),
+			})
+			return res, err
+		},
+		update: func(id string, option backupdr.AdvancedOptionRest) (*http.Response, error) {
+			_, res, err := client.SLATemplateApi.UpdateOptionForPolicy(ctx, sltID, policyID, id, &backupdr.SLATemplateApiUpdateOptionForPolicyOpts{
+				Body: optional.NewInterface(option),
+			})
+			return res, err
+		},
+		delete: func(id string) (*http.Response, error) {
+			return client.SLATemplateApi.DeleteOptionForPolicy(ctx, sltID, policyID, id)
+		},
+	}
+}
+
+// syncOptions makes the options of set match planned, matching options by
+// name. Options missing from planned are deleted, changed ones are updated
+// and new ones are created. A null planned map leaves the options alone.
+func syncOptions(set optionSet, planned types.Map) (*http.Response, error) {
+	if planned.IsNull() || planned.IsUnknown() {
+		return nil, nil
+	}
+	want := optionValues(planned)
+
+	current, res, err := set.list()
+	if err != nil {
+		return res, err
+	}
+	existing := make(map[string]bool, len(current))
+	for _, option := range current {
+		value, ok := want[option.Name]
+		switch {
+		case !ok:
+			if res, err := set.delete(option.Id); err != nil && !isNotFound(res, err) {
+				return res, err
+			}
+		case value != option.Value:
+			if res, err := set.update(option.Id, backupdr.AdvancedOptionRest{Id: option.Id, Name: option.Name, Value: value}); err != nil {
+				return res, err
+			}
+		}
+		existing[option.Name] = true
+	}
+
+	names := make([]string, 0, len(want))
+	for name := range want {
+		if !existing[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if res, err := set.create(backupdr.AdvancedOptionRest{Name: name, Value: want[name]}); err != nil {
+			return res, err
+		}
+	}
+	return nil, nil
+}
+
+// optionValues returns the known values of an options map.
+func optionValues(options types.Map) map[string]string {
+	values := make(map[string]string, len(options.Elements()))
+	for name, value := range options.Elements() {
+		if value, ok := value.(types.String); ok && !value.IsUnknown() {
+			values[name] = value.ValueString()
+		}
+	}
+	return values
+}
+
+// optionsValue returns the options read from the console as an options map.
+func optionsValue(options []backupdr.AdvancedOptionRest) types.Map {
+	values := make(map[string]attr.Value, len(options))
+	for _, option := range options {
+		values[option.Name] = types.StringValue(option.Value)
+	}
+	return types.MapValueMust(types.StringType, values)
+}
+
+// readOptions refreshes the options of set when they are managed, that is
+// when current is not null.
+func readOptions(set optionSet, current types.Map) (types.Map, *http.Response, error) {
+	if current.IsNull() {
+		return types.MapNull(types.StringType), nil, nil
+	}
+	options, res, err := set.list()
+	if err != nil {
+		return current, res, err
+	}
+	return optionsValue(options), nil, nil
+}
+
+// syncTemplateOptions sets the options of the template and of its policies
+// to the planned ones. Only the sets that differ from prior are synced; prior
+// is nil when the template was just created. When setting them fails, the
+// options of plan are read back from the console, since the sets synced
+// before the failure were changed.
+func (r *templateResource) syncTemplateOptions(ctx context.Context, plan *templateResourceModel, prior *templateResourceModel, diags *diag.Diagnostics) {
+	var failed diag.Diagnostics
+	r.setTemplateOptions(ctx, *plan, prior, &failed)
+	diags.Append(failed...)
+	if failed.HasError() {
+		r.readTemplateOptions(ctx, plan, diags)
+	}
+}
+
+// setTemplateOptions syncs the options of syncTemplateOptions, stopping at
+// the first set that fails.
+func (r *templateResource) setTemplateOptions(ctx context.Context, plan templateResourceModel, prior *templateResourceModel, diags *diag.Diagnostics) {
+	sltID := plan.ID.ValueString()
+	if prior == nil || !plan.Options.Equal(prior.Options) {
+		if res, err := syncOptions(templateOptionSet(ctx, r.clientConfig, sltID, plan.OptionHref.ValueString()), plan.Options); err != nil {
+			addConsoleError(diags,
+				"Error Setting SLT Options",
+				"Could not set the options of SLT Template "+sltID+".",
+				res, err, map[string]path.Path{"options": path.Root("options")},
+			)
+			return
+		}
+	}
+
+	for _, name := range sortedPolicyNames(plan.Policies) {
+		pol := plan.Policies[name]
+		if prior != nil {
+			if before, ok := prior.Policies[name]; ok && pol.Options.Equal(before.Options) {
+				continue
+			}
+		}
+		if pol.ID.ValueString() == "" {
+			continue
+		}
+		if res, err := syncOptions(policyOptionSet(ctx, r.client, sltID, pol.ID.ValueString()), pol.Options); err != nil {
+			addConsoleError(diags,
+				"Error Setting SLT Policy Options",
+				"Could not set the options of policy "+name+" of SLT Template "+sltID+".",
+				res, err, nil,
+			)
+			return
+		}
+	}
+}
+
+// readTemplateOptions refreshes the managed options of the template and of
+// its policies.
+func (r *templateResource) readTemplateOptions(ctx context.Context, state *templateResourceModel, diags *diag.Diagnostics) {
+	sltID := state.ID.ValueString()
+	options, res, err := readOptions(templateOptionSet(ctx, r.clientConfig, sltID, state.OptionHref.ValueString()), state.Options)
+	if err != nil {
+		addConsoleError(diags,
+			"Error Reading SLT Options",
+			"Could not read the options of SLT Template "+sltID+".",
+			res, err, nil,
+		)
+		return
+	}
+	state.Options = options
+
+	for _, name := range sortedPolicyNames(state.Policies) {
+		pol := state.Policies[name]
+		if pol.ID.ValueString() == "" {
+			continue
+		}
+		options, res, err := readOptions(policyOptionSet(ctx, r.client, sltID, pol.ID.ValueString()), pol.Options)
+		if err != nil {
+			addConsoleError(diags,
+				"Error Reading SLT Policy Options",
+				"Could not read the options of policy "+name+" of SLT Template "+sltID+".",
+				res, err, nil,
+			)
+			return
+		}
+		pol.Options = options
+		state.Policies[name] = pol
+	}
+}
@@ -0,0 +1,212 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	backupdr "github.com/umeshkumhar/backupdr-client"
+)
+
+// recordedOptions is an optionSet backed by a slice that records the calls
+// made to it.
+type recordedOptions struct {
+	options []backupdr.AdvancedOptionRest
+	calls   []string
+}
+
+func (o *recordedOptions) set() optionSet {
+	return optionSet{
+		list: func() ([]backupdr.AdvancedOptionRest, *http.Response, error) {
+			o.calls = append(o.calls, "list")
+			return o.options, nil, nil
+		},
+		create: func(option backupdr.AdvancedOptionRest) (*http.Response, error) {
+			o.calls = append(o.calls, "create "+option.Name+"="+option.Value)
+			return nil, nil
+		},
+		update: func(id string, option backupdr.AdvancedOptionRest) (*http.Response, error) {
+			o.calls = append(o.calls, "update "+id+" "+option.Name+"="+option.Value)
+			return nil, nil
+		},
+		delete: func(id string) (*http.Response, error) {
+			o.calls = append(o.calls, "delete "+id)
+			return nil, nil
+		},
+	}
+}
+
+func optionsMap(values map[string]string) types.Map {
+	elems := make(map[string]attr.Value, len(values))
+	for name, value := range values {
+		elems[name] = types.StringValue(value)
+	}
+	return types.MapValueMust(types.StringType, elems)
+}
+
+func TestSyncOptions(t *testing.T) {
+	current := []backupdr.AdvancedOptionRest{
+		{Id: "1", Name: "appconsistent", Value: "true"},
+		{Id: "2", Name: "jobtimeout", Value: "3600"},
+		{Id: "3", Name: "skiplunlevel", Value: "false"},
+	}
+
+	t.Run("sync", func(t *testing.T) {
+		options := &recordedOptions{options: current}
+		planned := optionsMap(map[string]string{"appconsistent": "true", "jobtimeout": "7200", "truncatelog": "true", "compress": "on"})
+		if _, err := syncOptions(options.set(), planned); err != nil {
+			t.Fatal(err)
+		}
+		want := []string{"list", "update 2 jobtimeout=7200", "delete 3", "create compress=on", "create truncatelog=true"}
+		if !reflect.DeepEqual(options.calls, want) {
+			t.Errorf("got calls %q, want %q", options.calls, want)
+		}
+	})
+
+	t.Run("unmanaged", func(t *testing.T) {
+		options := &recordedOptions{options: current}
+		if _, err := syncOptions(options.set(), types.MapNull(types.StringType)); err != nil {
+			t.Fatal(err)
+		}
+		if len(options.calls) != 0 {
+			t.Errorf("got calls %q for unmanaged options", options.calls)
+		}
+	})
+
+	t.Run("removed in the console", func(t *testing.T) {
+		options := &recordedOptions{options: current}
+		set := options.set()
+		set.delete = func(id string) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusNotFound}, errors.New("404 Not Found")
+		}
+		if _, err := syncOptions(set, optionsMap(nil)); err != nil {
+			t.Errorf("got %v for options already removed", err)
+		}
+	})
+}
+
+func TestReadOptions(t *testing.T) {
+	options := &recordedOptions{options: []backupdr.AdvancedOptionRest{{Id: "1", Name: "jobtimeout", Value: "7200"}}}
+
+	got, _, err := readOptions(options.set(), types.MapNull(types.StringType))
+	if err != nil || !got.IsNull() || len(options.calls) != 0 {
+		t.Errorf("got %s, %v and calls %q for unmanaged options", got, err, options.calls)
+	}
+
+	got, _, err = readOptions(options.set(), optionsMap(map[string]string{"jobtimeout": "3600"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := optionsMap(map[string]string{"jobtimeout": "7200"}); !got.Equal(want) {
+		t.Errorf("got %s, want the console options %s", got, want)
+	}
+}
+
+func TestConsoleRequest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/actifio/slt/1/option":
+			fmt.Fprint(w, `{"items":[{"id":"7","name":"jobtimeout","value":"3600"}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"err_code":10005,"err_message":"option not found"}`)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := backupdr.NewConfiguration()
+	cfg.Host = srv.URL
+	ctx := context.WithValue(context.Background(), backupdr.ContextAccessToken, "token")
+
+	var options backupdr.ListAdvancedOptionRest
+	if _, err := consoleRequest(ctx, cfg, http.MethodGet, "/slt/1/option", nil, &options); err != nil {
+		t.Fatal(err)
+	}
+	if len(options.Items) != 1 || options.Items[0].Name != "jobtimeout" {
+		t.Errorf("got options %+v", options.Items)
+	}
+
+	res, err := consoleRequest(ctx, cfg, http.MethodDelete, "/slt/1/option/8", nil, nil)
+	var reqErr *requestError
+	if !errors.As(err, &reqErr) || reqErr.Console == nil || reqErr.Console.ErrCode != 10005 {
+		t.Fatalf("got error %v, want the console error", err)
+	}
+	if !isNotFound(res, err) {
+		t.Errorf("got %v, want not found", err)
+	}
+}
+
+func TestTemplateOptionsPath(t *testing.T) {
+	cfg := backupdr.NewConfiguration()
+	tests := []struct {
+		href string
+		want string
+	}{
+		{"https://console.example.com/actifio/slt/1001/option", "/slt/1001/option"},
+		{"https://console.example.com/actifio/sltoption/1001", "/sltoption/1001"},
+		{"", "/slt/1001/option"},
+		{"https://console.example.com/other/slt/1001/option", "/slt/1001/option"},
+	}
+	for _, tt := range tests {
+		if got := templateOptionsPath(cfg, "1001", tt.href); got != tt.want {
+			t.Errorf("templateOptionsPath(%q) = %q, want %q", tt.href, got, tt.want)
+		}
+	}
+}
+
+func TestSyncTemplateOptions_refreshesOnFailure(t *testing.T) {
+	console := newFakeConsole(t)
+	sltID := console.Seed("slt", map[string]any{"name": "gold"})
+	policyID := console.Seed("slt/"+sltID+"/policy", map[string]any{"name": "daily"})
+	console.Seed("slt/"+sltID+"/option", map[string]any{"name": "jobtimeout", "value": "3600"})
+
+	// Setting the options of the policy fails after those of the template
+	// were set.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && strings.Contains(r.URL.Path, "/policy/") {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		console.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	cfg := backupdr.NewConfiguration()
+	cfg.Host = srv.URL
+	cfg.AddDefaultHeader(sessionHeader, sessionPrefix+" fake-session")
+	r := &templateResource{client: backupdr.NewAPIClient(cfg), clientConfig: cfg}
+
+	pol := emptyTemplatePolicy()
+	pol.ID = types.StringValue(policyID)
+	pol.Options = optionsMap(map[string]string{"compress": "on"})
+	plan := templateResourceModel{
+		ID:         types.StringValue(sltID),
+		OptionHref: types.StringValue("https://console.internal/actifio/slt/" + sltID + "/option"),
+		Options:    optionsMap(map[string]string{"jobtimeout": "7200"}),
+		Policies:   map[string]templatePolicyModel{"daily": pol},
+	}
+
+	var diags diag.Diagnostics
+	r.syncTemplateOptions(context.Background(), &plan, nil, &diags)
+	if !diags.HasError() {
+		t.Fatal("expected an error setting the policy options")
+	}
+	if want := optionsMap(map[string]string{"jobtimeout": "7200"}); !plan.Options.Equal(want) {
+		t.Errorf("got template options %s, want %s", plan.Options, want)
+	}
+	if got := plan.Policies["daily"].Options; !got.Equal(optionsMap(nil)) {
+		t.Errorf("got policy options %s, want those read back from the console", got)
+	}
+}
@@ -0,0 +1,340 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	backupdr "github.com/umeshkumhar/backupdr-client"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// upgradeTemplatePoliciesToMap moves the name of every policy in version 0
+// state to the key of the policy.
+func upgradeTemplatePoliciesToMap(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var rawState map[string]json.RawMessage
+	if err := json.Unmarshal(req.RawState.JSON, &rawState); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Upgrade Resource State",
+			"Could not parse prior state: "+err.Error(),
+		)
+		return
+	}
+
+	var policies []map[string]json.RawMessage
+	_ = json.Unmarshal(rawState["policies"], &policies)
+
+	if policies != nil {
+		byName := make(map[string]map[string]json.RawMessage, len(policies))
+		for _, policy := range policies {
+			var name string
+			_ = json.Unmarshal(policy["name"], &name)
+			if _, ok := byName[name]; ok {
+				resp.Diagnostics.AddError(
+					"Unable to Upgrade Resource State",
+					fmt.Sprintf("The template has several policies named %q. Policies are now keyed by name, so rename the policies to be unique in the management console and re-import the template.", name),
+				)
+				return
+			}
+			delete(policy, "name")
+			byName[name] = policy
+		}
+
+		upgraded, err := json.Marshal(byName)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Upgrade Resource State",
+				"Could not convert policies: "+err.Error(),
+			)
+			return
+		}
+		rawState["policies"] = upgraded
+	}
+
+	contents, err := json.Marshal(rawState)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Upgrade Resource State",
+			"Could not encode upgraded state: "+err.Error(),
+		)
+		return
+	}
+	resp.DynamicValue = &tfprotov6.DynamicValue{JSON: contents}
+}
+
+// sortedPolicyNames returns the names of policies in order, so policies are
+// sent to the console in a stable order.
+func sortedPolicyNames(policies map[string]templatePolicyModel) []string {
+	names := make([]string, 0, len(policies))
+	for name := range policies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// emptyTemplatePolicy returns a policy with every attribute null.
+func emptyTemplatePolicy() templatePolicyModel {
+	return templatePolicyModel{
+		DaysOfWeek:  types.ListNull(types.StringType),
+		DaysOfMonth: types.ListNull(types.Int64Type),
+		Months:      types.ListNull(types.StringType),
+		Options:     types.MapNull(types.StringType),
+	}
+}
+
+// policyChanged reports whether the planned policy differs from the prior
+// one. The options of the policy are left out, since they are updated
+// through their own requests, and an RPO or retention written in another
+// but equivalent form, such as `2w` for `14d`, is not a change.
+func policyChanged(pol, prior templatePolicyModel) bool {
+	if !pol.DaysOfWeek.Equal(prior.DaysOfWeek) || !pol.DaysOfMonth.Equal(prior.DaysOfMonth) || !pol.Months.Equal(prior.Months) {
+		return true
+	}
+	if sameDuration(pol.Rpo, pol.Rpom, prior.Rpo, prior.Rpom, rpoUnits) {
+		pol.Rpo, pol.Rpom = prior.Rpo, prior.Rpom
+	}
+	if sameDuration(pol.Retention, pol.Retentionm, prior.Retention, prior.Retentionm, retentionUnits) {
+		pol.Retention, pol.Retentionm = prior.Retention, prior.Retentionm
+	}
+	pol.DaysOfWeek, pol.DaysOfMonth, pol.Months, pol.Options = types.List{}, types.List{}, types.List{}, types.Map{}
+	prior.DaysOfWeek, prior.DaysOfMonth, prior.Months, prior.Options = types.List{}, types.List{}, types.List{}, types.Map{}
+	return !reflect.DeepEqual(pol, prior)
+}
+
+// sameDuration reports whether two duration attributes and the attributes
+// holding their units send the same duration to the console.
+func sameDuration(value, unit, prior, priorUnit types.String, units map[string]string) bool {
+	if value.IsUnknown() || prior.IsUnknown() {
+		return false
+	}
+	n1, u1 := consoleDuration(value, unit, units)
+	n2, u2 := consoleDuration(prior, priorUnit, units)
+	return equalDurations(n1, u1, n2, u2)
+}
+
+// newPolicyRest returns the console request of the named policy, converting
+// the start_time, end_time, rpo, retention and days attributes to the
+// console encoding.
+func newPolicyRest(name string, pol templatePolicyModel) backupdr.PolicyRest {
+	policy := backupdr.PolicyRest{
+		Name:          name,
+		Description:   pol.Description.ValueString(),
+		Priority:      pol.Priority.ValueString(),
+		Exclusiontype: pol.Exclusiontype.ValueString(),
+		Iscontinuous:  pol.Iscontinuous.ValueBool(),
+		Rpo:           pol.Rpo.ValueString(),
+		Rpom:          pol.Rpom.ValueString(),
+		Starttime:     pol.Starttime.ValueString(),
+		Endtime:       pol.Endtime.ValueString(),
+		Scheduletype:  pol.Scheduletype.ValueString(),
+		// Scheduling:        pol.Scheduling.ValueString(),
+		Targetvault:       int32(pol.Targetvault.ValueInt64()),
+		Sourcevault:       int32(pol.Sourcevault.ValueInt64()),
+		Selection:         pol.Selection.ValueString(),
+		Exclusion:         pol.Exclusion.ValueString(),
+		Exclusioninterval: pol.Exclusioninterval.ValueString(),
+		Retention:         pol.Retention.ValueString(),
+		Retentionm:        pol.Retentionm.ValueString(),
+		Remoteretention:   int32(pol.Remoteretention.ValueInt64()),
+		PolicyType:        pol.PolicyType.ValueString(),
+		Op:                pol.Op.ValueString(),
+		Verification:      pol.Verification.ValueBool(),
+		Repeatinterval:    pol.Repeatinterval.ValueString(),
+		Encrypt:           pol.Encrypt.ValueString(),
+		Reptype:           pol.Reptype.ValueString(),
+		Truncatelog:       pol.Truncatelog.ValueString(),
+		Verifychoice:      pol.Verifychoice.ValueString(),
+	}
+
+	if !pol.StartTime.IsNull() {
+		start, _ := policyTime(pol.StartTime.ValueString())
+		policy.Starttime = strconv.FormatInt(start, 10)
+	}
+	if !pol.EndTime.IsNull() {
+		end, _ := policyTime(pol.EndTime.ValueString())
+		policy.Endtime = strconv.FormatInt(end, 10)
+	}
+	if rpo, unit, ok := parseDuration(pol.Rpo.ValueString(), rpoUnits); ok {
+		policy.Rpo, policy.Rpom = rpo, unit
+	}
+	if retention, unit, ok := parseDuration(pol.Retention.ValueString(), retentionUnits); ok {
+		policy.Retention, policy.Retentionm = retention, unit
+	}
+	if selection := policySelection(listStrings(pol.DaysOfWeek), listInt64s(pol.DaysOfMonth), listStrings(pol.Months)); selection != "" {
+		policy.Selection = selection
+	}
+	return policy
+}
+
+// refreshTemplatePolicy returns pol with every field read from the console
+// policy, so changes made in the console show up as drift.
+func refreshTemplatePolicy(pol templatePolicyModel, policy backupdr.PolicyRest) templatePolicyModel {
+	pol.ID = types.StringValue(policy.Id)
+	pol.Href = types.StringValue(policy.Href)
+	pol.Description = optionalStringValue(pol.Description, policy.Description)
+	pol.Priority = optionalStringValue(pol.Priority, policy.Priority, "medium")
+	pol.Exclusiontype = optionalStringValue(pol.Exclusiontype, policy.Exclusiontype, "none")
+	pol.Scheduletype = optionalStringValue(pol.Scheduletype, policy.Scheduletype, "daily")
+	pol.Exclusion = optionalStringValue(pol.Exclusion, policy.Exclusion, "none")
+	pol.Reptype = optionalStringValue(pol.Reptype, policy.Reptype)
+	pol.Encrypt = optionalStringValue(pol.Encrypt, policy.Encrypt)
+	pol.Repeatinterval = optionalStringValue(pol.Repeatinterval, policy.Repeatinterval, "1")
+	pol.Exclusioninterval = optionalStringValue(pol.Exclusioninterval, policy.Exclusioninterval, "1")
+	pol.PolicyType = optionalStringValue(pol.PolicyType, policy.PolicyType)
+	pol.Truncatelog = optionalStringValue(pol.Truncatelog, policy.Truncatelog)
+	pol.Verifychoice = optionalStringValue(pol.Verifychoice, policy.Verifychoice)
+	pol.Op = optionalStringValue(pol.Op, policy.Op)
+	pol.Remoteretention = optionalInt64Value(pol.Remoteretention, int64(policy.Remoteretention))
+	pol.Targetvault = optionalInt64Value(pol.Targetvault, int64(policy.Targetvault))
+	pol.Sourcevault = optionalInt64Value(pol.Sourcevault, int64(policy.Sourcevault))
+	pol.Iscontinuous = optionalBoolValue(pol.Iscontinuous, policy.Iscontinuous)
+	pol.Verification = optionalBoolValue(pol.Verification, policy.Verification)
+
+	// The console encoding is read back in the form the policy was written
+	// in, so that an equivalent value is not reported as a change.
+	if pol.StartTime.IsNull() {
+		pol.Starttime = optionalStringValue(pol.Starttime, policy.Starttime)
+	} else {
+		pol.StartTime = clockValue(pol.StartTime, policy.Starttime)
+	}
+	if pol.EndTime.IsNull() {
+		pol.Endtime = optionalStringValue(pol.Endtime, policy.Endtime)
+	} else {
+		pol.EndTime = clockValue(pol.EndTime, policy.Endtime)
+	}
+	if isDuration(pol.Rpo.ValueString()) {
+		pol.Rpo = durationValue(pol.Rpo, policy.Rpo, policy.Rpom, rpoUnits)
+	} else {
+		pol.Rpom = optionalStringValue(pol.Rpom, policy.Rpom, "hours")
+		pol.Rpo = optionalStringValue(pol.Rpo, policy.Rpo)
+	}
+	if isDuration(pol.Retention.ValueString()) {
+		pol.Retention = durationValue(pol.Retention, policy.Retention, policy.Retentionm, retentionUnits)
+	} else {
+		pol.Retention = optionalStringValue(pol.Retention, policy.Retention)
+		pol.Retentionm = optionalStringValue(pol.Retentionm, policy.Retentionm, "days")
+	}
+
+	weekdays, monthdays, months, ok := parsePolicySelection(policy.Selection)
+	if !ok || pol.DaysOfWeek.IsNull() && pol.DaysOfMonth.IsNull() && pol.Months.IsNull() {
+		pol.Selection = optionalStringValue(pol.Selection, policy.Selection, "none")
+		weekdays, monthdays, months = nil, nil, nil
+		pol.DaysOfWeek, pol.DaysOfMonth, pol.Months = types.List{}, types.List{}, types.List{}
+	}
+	pol.DaysOfWeek = selectorValue(pol.DaysOfWeek, weekdays, types.StringType, func(v string) attr.Value { return types.StringValue(v) })
+	pol.DaysOfMonth = selectorValue(pol.DaysOfMonth, monthdays, types.Int64Type, func(v int64) attr.Value { return types.Int64Value(v) })
+	pol.Months = selectorValue(pol.Months, months, types.StringType, func(v string) attr.Value { return types.StringValue(v) })
+	return pol
+}
+
+// completeTemplatePolicy sets the attributes of pol that the console derives
+// when they are not set, such as the policy type, and that are unknown until
+// the policy is created or updated, from the console policy.
+func completeTemplatePolicy(pol templatePolicyModel, policy backupdr.PolicyRest) templatePolicyModel {
+	pol.ID = types.StringValue(policy.Id)
+	pol.Href = types.StringValue(policy.Href)
+	for _, f := range []struct {
+		attr  *types.String
+		value string
+	}{
+		{&pol.PolicyType, policy.PolicyType},
+		{&pol.Encrypt, policy.Encrypt},
+		{&pol.Reptype, policy.Reptype},
+		{&pol.Truncatelog, policy.Truncatelog},
+		{&pol.Verifychoice, policy.Verifychoice},
+	} {
+		if f.attr.IsUnknown() {
+			*f.attr = types.StringValue(f.value)
+		}
+	}
+	return pol
+}
+
+// checkPolicyNames adds an error and returns false when several policies of
+// template sltID share a name, since policies are keyed by name.
+func checkPolicyNames(sltID string, policies []backupdr.PolicyRest, diags *diag.Diagnostics) bool {
+	seen := make(map[string]bool, len(policies))
+	for _, policy := range policies {
+		if seen[policy.Name] {
+			diags.AddError(
+				"Duplicate SLT Policy Names",
+				fmt.Sprintf("SLT Template %s has several policies named %q. Policies are keyed by name, so rename the policies to be unique in the management console.", sltID, policy.Name),
+			)
+			return false
+		}
+		seen[policy.Name] = true
+	}
+	return true
+}
+
+// matchCreatedPolicies completes the planned policies of the new template
+// sltID from the console policies, matching them by name, since the console
+// does not list them in the order they were sent. A planned policy the
+// console does not list is reported, and gets an empty ID so that it is
+// created again by the next apply.
+func matchCreatedPolicies(sltID string, planned map[string]templatePolicyModel, policies []backupdr.PolicyRest, diags *diag.Diagnostics) {
+	for _, policy := range policies {
+		if pol, ok := planned[policy.Name]; ok {
+			planned[policy.Name] = completeTemplatePolicy(pol, policy)
+		}
+	}
+	for _, name := range sortedPolicyNames(planned) {
+		if pol := planned[name]; pol.ID.IsUnknown() {
+			diags.AddAttributeError(path.Root("policies").AtMapKey(name),
+				"Missing SLT Policy",
+				fmt.Sprintf("The management console did not list policy %q after creating SLT Template %s, so its ID is unknown. Check the policy in the management console; the next apply creates it again.", name, sltID),
+			)
+			planned[name] = completeTemplatePolicy(pol, backupdr.PolicyRest{})
+		}
+	}
+}
+
+// refreshTemplatePolicies reads the policies of a template from the console,
+// matching them to the prior policies by ID and then by name. Matched
+// policies are keyed by their name on the console. Prior policies missing from
+// the console are dropped, so they are planned to be created again, and
+// policies only on the console are added, so they are planned to be deleted.
+func refreshTemplatePolicies(prior map[string]templatePolicyModel, policies []backupdr.PolicyRest) map[string]templatePolicyModel {
+	byID := make(map[string]string, len(prior))
+	for name, pol := range prior {
+		if id := pol.ID.ValueString(); id != "" {
+			byID[id] = name
+		}
+	}
+
+	refreshed := make(map[string]templatePolicyModel, len(policies))
+	matched := make(map[string]bool, len(prior))
+	var unmatched []backupdr.PolicyRest
+	for _, policy := range policies {
+		name, ok := byID[policy.Id]
+		if !ok {
+			unmatched = append(unmatched, policy)
+			continue
+		}
+		refreshed[policy.Name] = refreshTemplatePolicy(prior[name], policy)
+		matched[name] = true
+	}
+	for _, policy := range unmatched {
+		pol := emptyTemplatePolicy()
+		if named, ok := prior[policy.Name]; ok && !matched[policy.Name] {
+			pol = named
+			matched[policy.Name] = true
+		}
+		refreshed[policy.Name] = refreshTemplatePolicy(pol, policy)
+	}
+
+	if len(refreshed) == 0 && prior == nil {
+		return nil
+	}
+	return refreshed
+}
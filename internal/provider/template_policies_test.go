@@ -0,0 +1,201 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	backupdr "github.com/umeshkumhar/backupdr-client"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// withNullCollections returns pol with the null days lists and options the
+// state holds.
+func withNullCollections(pol templatePolicyModel) templatePolicyModel {
+	empty := emptyTemplatePolicy()
+	pol.DaysOfWeek, pol.DaysOfMonth, pol.Months = empty.DaysOfWeek, empty.DaysOfMonth, empty.Months
+	pol.Options = empty.Options
+	return pol
+}
+
+func TestRefreshTemplatePolicies(t *testing.T) {
+	prior := map[string]templatePolicyModel{
+		"daily":   {ID: types.StringValue("11"), Href: types.StringValue("old"), Rpo: types.StringValue("24"), Iscontinuous: types.BoolValue(false)},
+		"weekly":  {ID: types.StringValue("12"), Rpo: types.StringValue("168")},
+		"hourly":  {ID: types.StringValue("13"), Rpo: types.StringValue("1")},
+		"monthly": {ID: types.StringValue("14"), Rpo: types.StringValue("720")},
+	}
+	for name, pol := range prior {
+		prior[name] = withNullCollections(pol)
+	}
+	// The console lists the policies in another order, has recreated
+	// weekly, has renamed hourly, has lost monthly, has an extra policy
+	// and has a changed RPO for daily.
+	policies := []backupdr.PolicyRest{
+		{Id: "21", Name: "weekly", Href: "weekly-href", Rpo: "168"},
+		{Id: "22", Name: "extra", Rpo: "12", Targetvault: 3},
+		{Id: "13", Name: "every-hour", Href: "hourly-href", Rpo: "1"},
+		{Id: "11", Name: "daily", Href: "daily-href", Rpo: "12"},
+	}
+
+	got := refreshTemplatePolicies(prior, policies)
+
+	want := map[string]templatePolicyModel{
+		"daily":      {ID: types.StringValue("11"), Href: types.StringValue("daily-href"), Rpo: types.StringValue("12"), Iscontinuous: types.BoolValue(false)},
+		"weekly":     {ID: types.StringValue("21"), Href: types.StringValue("weekly-href"), Rpo: types.StringValue("168")},
+		"every-hour": {ID: types.StringValue("13"), Href: types.StringValue("hourly-href"), Rpo: types.StringValue("1")},
+		"extra":      {ID: types.StringValue("22"), Href: types.StringValue(""), Rpo: types.StringValue("12"), Targetvault: types.Int64Value(3)},
+	}
+	for name, pol := range want {
+		want[name] = withNullCollections(pol)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got policies %+v, want %+v", got, want)
+	}
+
+	if got := refreshTemplatePolicies(nil, nil); got != nil {
+		t.Errorf("got %v for a template without policies, want nil", got)
+	}
+}
+
+func TestCheckPolicyNames(t *testing.T) {
+	var diags diag.Diagnostics
+	if !checkPolicyNames("1001", []backupdr.PolicyRest{{Id: "11", Name: "daily"}, {Id: "12", Name: "weekly"}}, &diags) || diags.HasError() {
+		t.Errorf("got %v for unique policy names", diags)
+	}
+	if checkPolicyNames("1001", []backupdr.PolicyRest{{Id: "11", Name: "daily"}, {Id: "12", Name: "daily"}}, &diags) || !diags.HasError() {
+		t.Error("expected an error for duplicate policy names")
+	}
+}
+
+func TestMatchCreatedPolicies(t *testing.T) {
+	planned := map[string]templatePolicyModel{}
+	for _, name := range []string{"daily", "weekly"} {
+		pol := emptyTemplatePolicy()
+		pol.ID, pol.Href, pol.PolicyType = types.StringUnknown(), types.StringUnknown(), types.StringUnknown()
+		planned[name] = pol
+	}
+
+	var diags diag.Diagnostics
+	matchCreatedPolicies("1001", planned, []backupdr.PolicyRest{{Id: "11", Name: "daily", Href: "daily-href", PolicyType: "snapshot"}}, &diags)
+
+	if daily := planned["daily"]; daily.ID.ValueString() != "11" || daily.PolicyType.ValueString() != "snapshot" {
+		t.Errorf("got daily policy %+v, want it completed from the console", daily)
+	}
+	if weekly := planned["weekly"]; weekly.ID.IsUnknown() || weekly.Href.IsUnknown() || weekly.PolicyType.IsUnknown() {
+		t.Errorf("got unknown values in the missing weekly policy %+v", weekly)
+	}
+	if len(diags) != 1 {
+		t.Fatalf("got %v, want an error for the missing weekly policy", diags)
+	}
+	if d, ok := diags[0].(diag.DiagnosticWithPath); !ok || !d.Path().Equal(path.Root("policies").AtMapKey("weekly")) {
+		t.Errorf("got %v, want it reported at the weekly policy", diags[0])
+	}
+}
+
+func TestUpgradeTemplatePoliciesToMap(t *testing.T) {
+	prior := `{"id":"1001","name":"gold","policies":[{"id":"11","name":"daily","rpo":"24"},{"id":"12","name":"weekly","rpo":"168"}]}`
+
+	req := resource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: []byte(prior)}}
+	resp := &resource.UpgradeStateResponse{}
+	upgradeTemplatePoliciesToMap(context.Background(), req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	var upgraded struct {
+		Name     string                       `json:"name"`
+		Policies map[string]map[string]string `json:"policies"`
+	}
+	if err := json.Unmarshal(resp.DynamicValue.JSON, &upgraded); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]map[string]string{
+		"daily":  {"id": "11", "rpo": "24"},
+		"weekly": {"id": "12", "rpo": "168"},
+	}
+	if !reflect.DeepEqual(upgraded.Policies, want) {
+		t.Errorf("got policies %v, want %v", upgraded.Policies, want)
+	}
+	if upgraded.Name != "gold" {
+		t.Errorf("other attributes were not preserved: %+v", upgraded)
+	}
+
+	duplicate := `{"id":"1001","policies":[{"id":"11","name":"daily"},{"id":"12","name":"daily"}]}`
+	resp = &resource.UpgradeStateResponse{}
+	upgradeTemplatePoliciesToMap(context.Background(), resource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: []byte(duplicate)}}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Error("expected an error for duplicate policy names")
+	}
+}
+
+func TestTemplatePolicyHumanForms(t *testing.T) {
+	pol := withNullCollections(templatePolicyModel{
+		StartTime: types.StringValue("19:00"),
+		EndTime:   types.StringValue("06:30"),
+		Rpo:       types.StringValue("4h"),
+		Retention: types.StringValue("30d"),
+	})
+	pol.DaysOfWeek = types.ListValueMust(types.StringType, []attr.Value{types.StringValue("sat"), types.StringValue("sun")})
+
+	policy := newPolicyRest("weekend", pol)
+	if policy.Starttime != "68400" || policy.Endtime != "23400" {
+		t.Errorf("got window %s-%s, want 68400-23400", policy.Starttime, policy.Endtime)
+	}
+	if policy.Rpo != "4" || policy.Rpom != "hours" {
+		t.Errorf("got RPO %s %s, want 4 hours", policy.Rpo, policy.Rpom)
+	}
+	if policy.Retention != "30" || policy.Retentionm != "days" {
+		t.Errorf("got retention %s %s, want 30 days", policy.Retention, policy.Retentionm)
+	}
+	if policy.Selection != "weekday:sat,sun" {
+		t.Errorf("got selection %q, want weekday:sat,sun", policy.Selection)
+	}
+
+	// The console returns the days in another order.
+	policy.Id = "11"
+	policy.Selection = "weekday:sun,sat"
+	refreshed := refreshTemplatePolicy(pol, policy)
+	refreshed.ID, refreshed.Href = types.StringNull(), types.StringNull()
+	if policyChanged(refreshed, pol) {
+		t.Errorf("got %+v read back from the console, want %+v", refreshed, pol)
+	}
+
+	// An equivalent RPO or retention is not a change, a different one is.
+	respelled := pol
+	respelled.Rpo, respelled.Retention = types.StringValue("240min"), types.StringValue("30d")
+	if policyChanged(respelled, pol) {
+		t.Error("got a change for an RPO of 240min instead of 4h")
+	}
+	respelled.Rpo, respelled.Rpom = types.StringValue("4"), types.StringValue("hours")
+	if policyChanged(respelled, pol) {
+		t.Error("got a change for an RPO of 4 hours instead of 4h")
+	}
+	respelled.Retention = types.StringValue("1mo")
+	if !policyChanged(respelled, pol) {
+		t.Error("got no change for a retention of 1mo instead of 30d")
+	}
+
+	// Changes made in the console are read back in the human form.
+	policy.Endtime = "25200"
+	policy.Rpo, policy.Rpom = "30", "minutes"
+	policy.Retention, policy.Retentionm = "2", "weeks"
+	policy.Selection = "weekday:sun"
+	refreshed = refreshTemplatePolicy(pol, policy)
+	if refreshed.EndTime.ValueString() != "07:00" || refreshed.Rpo.ValueString() != "30min" || refreshed.Retention.ValueString() != "2w" {
+		t.Errorf("got end_time %s, rpo %s and retention %s, want 07:00, 30min and 2w", refreshed.EndTime, refreshed.Rpo, refreshed.Retention)
+	}
+	if !refreshed.Endtime.IsNull() || !refreshed.Rpom.IsNull() || !refreshed.Retentionm.IsNull() || !refreshed.Selection.IsNull() {
+		t.Errorf("got console fields set in %+v, want them null", refreshed)
+	}
+	if got := listStrings(refreshed.DaysOfWeek); !reflect.DeepEqual(got, []string{"sun"}) {
+		t.Errorf("got days_of_week %v, want [sun]", got)
+	}
+}
@@ -0,0 +1,457 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Accepted values of the typed policy block attributes.
+var (
+	policyPriorities     = []string{"low", "medium", "high"}
+	policySchedules      = []string{"daily", "weekly", "monthly", "yearly"}
+	policyRetentionUnits = []string{"days", "weeks", "months", "years"}
+)
+
+// maxOnVaultPool is the number of OnVault pools a resource profile can have.
+const maxOnVaultPool = 4
+
+// scheduledPolicyAttributes returns the attributes shared by the snapshot,
+// OnVault and replication policy blocks.
+func scheduledPolicyAttributes(kind string) map[string]schema.Attribute {
+	return withPolicyDays(map[string]schema.Attribute{
+		"options": optionsAttribute(kind + " policy"),
+		"name": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: "Provide the name of the " + kind + " policy. It must be unique within the template.",
+		},
+		"description": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Provide the description of the " + kind + " policy.",
+		},
+		"priority": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Provide the job priority: `low`, `medium` or `high`. Defaults to `medium`.",
+		},
+		"schedule": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Provide how often the policy runs: `daily`, `weekly`, `monthly` or `yearly`. Defaults to `daily`.",
+		},
+		"selection": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Provide the days the policy runs on in the management console `selection` format. Prefer `days_of_week`, `days_of_month` and `months`. Defaults to `none`, which runs every day of the schedule.",
+		},
+		"start_time": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: "Provide the start of the backup window as `HH:MM`, for example `19:00`.",
+		},
+		"end_time": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: "Provide the end of the backup window as `HH:MM`. A window ending before it starts ends on the next day.",
+		},
+		"rpo_hours": schema.Int64Attribute{
+			Optional:            true,
+			MarkdownDescription: "Provide how many hours apart the jobs run within the window, from 1 to 24. Defaults to `24`, once per window.",
+		},
+		"retention": schema.Int64Attribute{
+			Required:            true,
+			MarkdownDescription: "Provide how long to keep the images, in `retention_unit`.",
+		},
+		"retention_unit": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Provide the unit of `retention`: `days`, `weeks`, `months` or `years`. Defaults to `days`.",
+		},
+	})
+}
+
+// withPolicyDays adds the attributes selecting the days a policy runs on to
+// attributes.
+func withPolicyDays(attributes map[string]schema.Attribute) map[string]schema.Attribute {
+	attributes["days_of_week"] = schema.ListAttribute{
+		ElementType:         types.StringType,
+		Optional:            true,
+		MarkdownDescription: "Provide the days of the week a weekly or monthly policy runs on, such as `[\"sat\", \"sun\"]`, instead of `selection`.",
+	}
+	attributes["days_of_month"] = schema.ListAttribute{
+		ElementType:         types.Int64Type,
+		Optional:            true,
+		MarkdownDescription: "Provide the days of the month, from 1 to 31, a monthly or yearly policy runs on, instead of `selection`.",
+	}
+	attributes["months"] = schema.ListAttribute{
+		ElementType:         types.StringType,
+		Optional:            true,
+		MarkdownDescription: "Provide the months a yearly policy runs in, such as `[\"jan\"]`, instead of `selection`.",
+	}
+	return attributes
+}
+
+// templatePolicyBlocks returns the typed policy blocks of backupdr_template.
+func templatePolicyBlocks() map[string]schema.Block {
+	onVault := scheduledPolicyAttributes("OnVault")
+	onVault["pool"] = schema.Int64Attribute{
+		Optional:            true,
+		MarkdownDescription: "Provide the OnVault pool of the resource profile to copy the snapshots to, from 1 to 4. Defaults to `1`.",
+	}
+
+	replication := scheduledPolicyAttributes("replication")
+	replication["source_pool"] = schema.Int64Attribute{
+		Required:            true,
+		MarkdownDescription: "Provide the OnVault pool of the resource profile to replicate from, from 1 to 4.",
+	}
+	replication["target_pool"] = schema.Int64Attribute{
+		Required:            true,
+		MarkdownDescription: "Provide the OnVault pool of the resource profile to replicate to, from 1 to 4. It must differ from `source_pool`.",
+	}
+
+	return map[string]schema.Block{
+		"snapshot_policy": schema.ListNestedBlock{
+			MarkdownDescription: "Define a snapshot policy. It is translated to a policy with `op` set to `snap`. Database log backups are settings of the snapshot policy: set them with the log backup advanced options of the policy in `options`. Conflicts with `policies`.",
+			NestedObject:        schema.NestedBlockObject{Attributes: scheduledPolicyAttributes("snapshot")},
+		},
+		"onvault_policy": schema.ListNestedBlock{
+			MarkdownDescription: "Define a policy copying snapshots to an OnVault pool. It is translated to a policy with `op` set to `cloud`. Conflicts with `policies`.",
+			NestedObject:        schema.NestedBlockObject{Attributes: onVault},
+		},
+		"replication_policy": schema.ListNestedBlock{
+			MarkdownDescription: "Define a policy replicating images from one OnVault pool to another. It is translated to a policy with `op` set to `cloud` between the two pools; `reptype` is left unset, since it only applies to mirror policies, which are set with `policies`. Conflicts with `policies`.",
+			NestedObject:        schema.NestedBlockObject{Attributes: replication},
+		},
+	}
+}
+
+// scheduledPolicy holds the attributes shared by the snapshot, OnVault and
+// replication policy blocks.
+type scheduledPolicy struct {
+	Name          types.String
+	Description   types.String
+	Priority      types.String
+	Schedule      types.String
+	Selection     types.String
+	StartTime     types.String
+	EndTime       types.String
+	RPOHours      types.Int64
+	Retention     types.Int64
+	RetentionUnit types.String
+	DaysOfWeek    types.List
+	DaysOfMonth   types.List
+	Months        types.List
+	Options       types.Map
+}
+
+func (m snapshotPolicyModel) scheduled() scheduledPolicy {
+	return scheduledPolicy{m.Name, m.Description, m.Priority, m.Schedule, m.Selection, m.StartTime, m.EndTime, m.RPOHours, m.Retention, m.RetentionUnit, m.DaysOfWeek, m.DaysOfMonth, m.Months, m.Options}
+}
+
+func (m onVaultPolicyModel) scheduled() scheduledPolicy {
+	return scheduledPolicy{m.Name, m.Description, m.Priority, m.Schedule, m.Selection, m.StartTime, m.EndTime, m.RPOHours, m.Retention, m.RetentionUnit, m.DaysOfWeek, m.DaysOfMonth, m.Months, m.Options}
+}
+
+func (m replicationPolicyModel) scheduled() scheduledPolicy {
+	return scheduledPolicy{m.Name, m.Description, m.Priority, m.Schedule, m.Selection, m.StartTime, m.EndTime, m.RPOHours, m.Retention, m.RetentionUnit, m.DaysOfWeek, m.DaysOfMonth, m.Months, m.Options}
+}
+
+// stringOr returns the value of s, or def when s is null.
+func stringOr(s types.String, def string) string {
+	if s.IsNull() {
+		return def
+	}
+	return s.ValueString()
+}
+
+// int64Or returns the value of i, or def when i is null.
+func int64Or(i types.Int64, def int64) int64 {
+	if i.IsNull() {
+		return def
+	}
+	return i.ValueInt64()
+}
+
+// policy translates the block to a policy running op between the sourcevault
+// and targetvault pools. Attributes the block does not decide keep their
+// values in pol.
+func (s scheduledPolicy) policy(pol templatePolicyModel, op string, sourcevault, targetvault types.Int64) templatePolicyModel {
+	start, _ := policyTime(s.StartTime.ValueString())
+	end, _ := policyTime(s.EndTime.ValueString())
+
+	pol.Description = s.Description
+	pol.Options = policyOptions(s.Options)
+	pol.Op = types.StringValue(op)
+	pol.Priority = types.StringValue(stringOr(s.Priority, "medium"))
+	pol.Scheduletype = types.StringValue(stringOr(s.Schedule, "daily"))
+	pol.Selection = types.StringValue(stringOr(s.Selection, "none"))
+	if selection := policySelection(listStrings(s.DaysOfWeek), listInt64s(s.DaysOfMonth), listStrings(s.Months)); selection != "" {
+		pol.Selection = types.StringValue(selection)
+	}
+	pol.Starttime = types.StringValue(strconv.FormatInt(start, 10))
+	pol.Endtime = types.StringValue(strconv.FormatInt(end, 10))
+	pol.Iscontinuous = types.BoolValue(false)
+	pol.Rpo = types.StringValue(strconv.FormatInt(int64Or(s.RPOHours, 24), 10))
+	pol.Rpom = types.StringValue("hours")
+	pol.Retention = types.StringValue(strconv.FormatInt(s.Retention.ValueInt64(), 10))
+	pol.Retentionm = types.StringValue(stringOr(s.RetentionUnit, "days"))
+	pol.Sourcevault = sourcevault
+	pol.Targetvault = targetvault
+	pol.Repeatinterval = types.StringValue("1")
+	pol.Exclusiontype = types.StringValue("none")
+	pol.Exclusioninterval = types.StringValue("1")
+	pol.Exclusion = types.StringValue("none")
+	return pol
+}
+
+// policyOptions returns the options of a policy block, null when unset.
+func policyOptions(options types.Map) types.Map {
+	if options.IsNull() {
+		return types.MapNull(types.StringType)
+	}
+	return options
+}
+
+// hasPolicyBlocks reports whether the template uses the typed policy blocks.
+func (m templateResourceModel) hasPolicyBlocks() bool {
+	return len(m.SnapshotPolicies)+len(m.OnVaultPolicies)+len(m.ReplicationPolicies) > 0
+}
+
+// translatePolicyBlocks returns the policies the typed policy blocks of
+// config translate to, or nil when there are none. Policies start from the
+// prior policy of the same name, so they keep its ID and the attributes the
+// console fills in; new policies have an unknown ID and href.
+func translatePolicyBlocks(config templateResourceModel, prior map[string]templatePolicyModel) map[string]templatePolicyModel {
+	if !config.hasPolicyBlocks() {
+		return nil
+	}
+
+	policies := map[string]templatePolicyModel{}
+	start := func(name string) templatePolicyModel {
+		if pol, ok := prior[name]; ok && !pol.ID.IsNull() {
+			return pol
+		}
+		pol := emptyTemplatePolicy()
+		pol.ID, pol.Href = types.StringUnknown(), types.StringUnknown()
+		// The console derives these when the policy is created.
+		pol.PolicyType, pol.Encrypt, pol.Reptype = types.StringUnknown(), types.StringUnknown(), types.StringUnknown()
+		pol.Truncatelog, pol.Verifychoice = types.StringUnknown(), types.StringUnknown()
+		return pol
+	}
+	for _, m := range config.SnapshotPolicies {
+		name := m.Name.ValueString()
+		policies[name] = m.scheduled().policy(start(name), "snap", types.Int64Null(), types.Int64Null())
+	}
+	for _, m := range config.OnVaultPolicies {
+		name := m.Name.ValueString()
+		policies[name] = m.scheduled().policy(start(name), "cloud", types.Int64Value(0), types.Int64Value(int64Or(m.Pool, 1)))
+	}
+	// The console models replication between OnVault pools as a cloud
+	// policy from the source to the target pool. reptype selects the
+	// transport of mirror policies and does not apply to it.
+	for _, m := range config.ReplicationPolicies {
+		name := m.Name.ValueString()
+		policies[name] = m.scheduled().policy(start(name), "cloud", m.SourcePool, m.TargetPool)
+	}
+	return policies
+}
+
+// policyValidator collects the errors of the typed policy blocks and of the
+// raw policies.
+type policyValidator struct {
+	diags *diag.Diagnostics
+	names map[string]bool
+}
+
+// name checks that the policy name at p is not used by another block.
+func (v policyValidator) name(p path.Path, name types.String) {
+	if name.IsNull() || name.IsUnknown() {
+		return
+	}
+	if v.names[name.ValueString()] {
+		v.diags.AddAttributeError(p, "Duplicate Policy Name",
+			fmt.Sprintf("Another policy block of the template is named %q. Policy names must be unique within a template.", name.ValueString()))
+	}
+	v.names[name.ValueString()] = true
+}
+
+// oneOf checks that the value at p is one of values.
+func (v policyValidator) oneOf(p path.Path, value types.String, values []string) {
+	if value.IsNull() || value.IsUnknown() {
+		return
+	}
+	for _, allowed := range values {
+		if value.ValueString() == allowed {
+			return
+		}
+	}
+	v.diags.AddAttributeError(p, "Invalid Attribute Value",
+		fmt.Sprintf("%s must be one of `%s`, got %q.", p, strings.Join(values, "`, `"), value.ValueString()))
+}
+
+// between checks that the value at p is within [low, high]. A high of zero
+// leaves the value unbounded above.
+func (v policyValidator) between(p path.Path, value types.Int64, low, high int64) {
+	if value.IsNull() || value.IsUnknown() {
+		return
+	}
+	if n := value.ValueInt64(); n < low || (high > 0 && n > high) {
+		if high > 0 {
+			v.diags.AddAttributeError(p, "Invalid Attribute Value",
+				fmt.Sprintf("%s must be from %d to %d, got %d.", p, low, high, n))
+		} else {
+			v.diags.AddAttributeError(p, "Invalid Attribute Value",
+				fmt.Sprintf("%s must be at least %d, got %d.", p, low, n))
+		}
+	}
+}
+
+// time checks that the value at p is an `HH:MM` time.
+func (v policyValidator) time(p path.Path, value types.String) {
+	if value.IsNull() || value.IsUnknown() {
+		return
+	}
+	if _, err := policyTime(value.ValueString()); err != nil {
+		v.diags.AddAttributeError(p, "Invalid Attribute Value", err.Error()+".")
+	}
+}
+
+// conflict checks that the values at p and at other are not both set.
+func (v policyValidator) conflict(p path.Path, value attr.Value, other string, otherValue attr.Value) {
+	if !value.IsNull() && !otherValue.IsNull() {
+		v.diags.AddAttributeError(p, "Conflicting Attribute Values",
+			fmt.Sprintf("%s cannot be set together with %s.", p, other))
+	}
+}
+
+// duration checks that a value at p given with a unit suffix uses one of
+// units, and that the number is then not given a unit through unitName too.
+func (v policyValidator) duration(p path.Path, value types.String, units map[string]string, unitName string, unit types.String) {
+	if value.IsUnknown() || !isDuration(value.ValueString()) {
+		return
+	}
+	if _, _, ok := parseDuration(value.ValueString(), units); !ok {
+		suffixes := make([]string, 0, len(units))
+		for suffix, u := range units {
+			suffixes = append(suffixes, fmt.Sprintf("`%s` (%s)", suffix, u))
+		}
+		sort.Strings(suffixes)
+		v.diags.AddAttributeError(p, "Invalid Attribute Value",
+			fmt.Sprintf("%s must be a number, or a positive number followed by one of %s, got %q.", p, strings.Join(suffixes, ", "), value.ValueString()))
+		return
+	}
+	v.conflict(p.ParentPath().AtName(unitName), unit, p.String()+" with a unit", value)
+}
+
+// days checks the days a policy at p runs on.
+func (v policyValidator) days(p path.Path, weekdays, monthdays, months types.List, selection types.String) {
+	for i, day := range weekdays.Elements() {
+		if day, ok := day.(types.String); ok {
+			v.oneOf(p.AtName("days_of_week").AtListIndex(i), day, policyWeekdays)
+		}
+	}
+	for i, day := range monthdays.Elements() {
+		if day, ok := day.(types.Int64); ok {
+			v.between(p.AtName("days_of_month").AtListIndex(i), day, 1, 31)
+		}
+	}
+	for i, month := range months.Elements() {
+		if month, ok := month.(types.String); ok {
+			v.oneOf(p.AtName("months").AtListIndex(i), month, policyMonths)
+		}
+	}
+	v.conflict(p.AtName("days_of_week"), weekdays, "selection", selection)
+	v.conflict(p.AtName("days_of_month"), monthdays, "selection", selection)
+	v.conflict(p.AtName("months"), months, "selection", selection)
+}
+
+// raw checks the human-readable attributes of the raw policy at p.
+func (v policyValidator) raw(p path.Path, pol templatePolicyModel) {
+	v.time(p.AtName("start_time"), pol.StartTime)
+	v.time(p.AtName("end_time"), pol.EndTime)
+	v.conflict(p.AtName("start_time"), pol.StartTime, "starttime", pol.Starttime)
+	v.conflict(p.AtName("end_time"), pol.EndTime, "endtime", pol.Endtime)
+	v.duration(p.AtName("rpo"), pol.Rpo, rpoUnits, "rpom", pol.Rpom)
+	v.duration(p.AtName("retention"), pol.Retention, retentionUnits, "retentionm", pol.Retentionm)
+	v.days(p, pol.DaysOfWeek, pol.DaysOfMonth, pol.Months, pol.Selection)
+}
+
+// scheduled checks the attributes shared by the scheduled policy blocks at p.
+func (v policyValidator) scheduled(p path.Path, s scheduledPolicy) {
+	v.name(p.AtName("name"), s.Name)
+	v.oneOf(p.AtName("priority"), s.Priority, policyPriorities)
+	v.oneOf(p.AtName("schedule"), s.Schedule, policySchedules)
+	v.time(p.AtName("start_time"), s.StartTime)
+	v.time(p.AtName("end_time"), s.EndTime)
+	v.between(p.AtName("rpo_hours"), s.RPOHours, 1, 24)
+	v.between(p.AtName("retention"), s.Retention, 1, 0)
+	v.oneOf(p.AtName("retention_unit"), s.RetentionUnit, policyRetentionUnits)
+	v.days(p, s.DaysOfWeek, s.DaysOfMonth, s.Months, s.Selection)
+}
+
+// validateTemplatePolicies checks the raw policies and the typed policy
+// blocks of config, and that they are not used together.
+func validateTemplatePolicies(ctx context.Context, config templateResourceModel, policies types.Map, diags *diag.Diagnostics) {
+	if !policies.IsNull() && config.hasPolicyBlocks() {
+		diags.AddAttributeError(path.Root("policies"), "Conflicting Policy Configuration",
+			"policies cannot be set together with the snapshot_policy, onvault_policy or replication_policy blocks. Use either the typed blocks or the raw policies.")
+	}
+
+	v := policyValidator{diags: diags, names: map[string]bool{}}
+	for name, elem := range policies.Elements() {
+		obj, ok := elem.(types.Object)
+		if !ok || obj.IsUnknown() {
+			continue
+		}
+		var pol templatePolicyModel
+		diags.Append(obj.As(ctx, &pol, basetypes.ObjectAsOptions{})...)
+		if diags.HasError() {
+			return
+		}
+		v.raw(path.Root("policies").AtMapKey(name), pol)
+	}
+	for i, m := range config.SnapshotPolicies {
+		v.scheduled(path.Root("snapshot_policy").AtListIndex(i), m.scheduled())
+	}
+	for i, m := range config.OnVaultPolicies {
+		p := path.Root("onvault_policy").AtListIndex(i)
+		v.scheduled(p, m.scheduled())
+		v.between(p.AtName("pool"), m.Pool, 1, maxOnVaultPool)
+	}
+	for i, m := range config.ReplicationPolicies {
+		p := path.Root("replication_policy").AtListIndex(i)
+		v.scheduled(p, m.scheduled())
+		v.between(p.AtName("source_pool"), m.SourcePool, 1, maxOnVaultPool)
+		v.between(p.AtName("target_pool"), m.TargetPool, 1, maxOnVaultPool)
+		if !m.SourcePool.IsNull() && !m.SourcePool.IsUnknown() && m.SourcePool.Equal(m.TargetPool) {
+			diags.AddAttributeError(p.AtName("target_pool"), "Invalid Attribute Value",
+				fmt.Sprintf("target_pool must differ from source_pool, both are %d.", m.SourcePool.ValueInt64()))
+		}
+	}
+}
+
+// getPolicyBlocks reads the typed policy blocks of a template configuration
+// into config. It returns false when a block list is unknown, such as a
+// dynamic block over an unknown value.
+func getPolicyBlocks(ctx context.Context, get func(context.Context, path.Path, any) diag.Diagnostics, config *templateResourceModel, diags *diag.Diagnostics) bool {
+	blocks := map[string]any{
+		"snapshot_policy":    &config.SnapshotPolicies,
+		"onvault_policy":     &config.OnVaultPolicies,
+		"replication_policy": &config.ReplicationPolicies,
+	}
+	for name, target := range blocks {
+		var list types.List
+		diags.Append(get(ctx, path.Root(name), &list)...)
+		if diags.HasError() || list.IsUnknown() {
+			return false
+		}
+		diags.Append(list.ElementsAs(ctx, target, false)...)
+		if diags.HasError() {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,264 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestTranslatePolicyBlocks(t *testing.T) {
+	config := templateResourceModel{
+		SnapshotPolicies: []snapshotPolicyModel{{
+			Name:      types.StringValue("daily"),
+			StartTime: types.StringValue("19:00"),
+			EndTime:   types.StringValue("07:00"),
+			Retention: types.Int64Value(14),
+		}},
+		OnVaultPolicies: []onVaultPolicyModel{{
+			Name:          types.StringValue("vault"),
+			Priority:      types.StringValue("high"),
+			Schedule:      types.StringValue("weekly"),
+			Selection:     types.StringValue("sun"),
+			StartTime:     types.StringValue("01:00"),
+			EndTime:       types.StringValue("05:30"),
+			Retention:     types.Int64Value(8),
+			RetentionUnit: types.StringValue("weeks"),
+			Pool:          types.Int64Value(2),
+		}},
+		ReplicationPolicies: []replicationPolicyModel{{
+			Name:       types.StringValue("replica"),
+			StartTime:  types.StringValue("02:00"),
+			EndTime:    types.StringValue("06:00"),
+			RPOHours:   types.Int64Value(12),
+			Retention:  types.Int64Value(30),
+			SourcePool: types.Int64Value(1),
+			TargetPool: types.Int64Value(3),
+		}},
+	}
+	// The snapshot policy exists, with an attribute the console filled in.
+	prior := map[string]templatePolicyModel{
+		"daily": withNullCollections(templatePolicyModel{ID: types.StringValue("11"), Href: types.StringValue("daily-href"), Encrypt: types.StringValue("secure"), Retention: types.StringValue("2")}),
+	}
+
+	got := translatePolicyBlocks(config, prior)
+
+	scheduled := withNullCollections(templatePolicyModel{
+		Priority:          types.StringValue("medium"),
+		Scheduletype:      types.StringValue("daily"),
+		Selection:         types.StringValue("none"),
+		Iscontinuous:      types.BoolValue(false),
+		Rpo:               types.StringValue("24"),
+		Rpom:              types.StringValue("hours"),
+		Retentionm:        types.StringValue("days"),
+		Repeatinterval:    types.StringValue("1"),
+		Exclusiontype:     types.StringValue("none"),
+		Exclusioninterval: types.StringValue("1"),
+		Exclusion:         types.StringValue("none"),
+	})
+	daily := scheduled
+	daily.ID = types.StringValue("11")
+	daily.Href = types.StringValue("daily-href")
+	daily.Encrypt = types.StringValue("secure")
+	daily.Op = types.StringValue("snap")
+	daily.Starttime = types.StringValue("68400")
+	daily.Endtime = types.StringValue("25200")
+	daily.Retention = types.StringValue("14")
+
+	vault := scheduled
+	vault.ID = types.StringUnknown()
+	vault.Href = types.StringUnknown()
+	vault.Op = types.StringValue("cloud")
+	vault.Priority = types.StringValue("high")
+	vault.Scheduletype = types.StringValue("weekly")
+	vault.Selection = types.StringValue("sun")
+	vault.Starttime = types.StringValue("3600")
+	vault.Endtime = types.StringValue("19800")
+	vault.Retention = types.StringValue("8")
+	vault.Retentionm = types.StringValue("weeks")
+	vault.Sourcevault = types.Int64Value(0)
+	vault.Targetvault = types.Int64Value(2)
+
+	replica := scheduled
+	replica.ID = types.StringUnknown()
+	replica.Href = types.StringUnknown()
+	replica.Op = types.StringValue("cloud")
+	replica.Starttime = types.StringValue("7200")
+	replica.Endtime = types.StringValue("21600")
+	replica.Rpo = types.StringValue("12")
+	replica.Retention = types.StringValue("30")
+	replica.Sourcevault = types.Int64Value(1)
+	replica.Targetvault = types.Int64Value(3)
+
+	want := map[string]templatePolicyModel{"daily": daily, "vault": vault, "replica": replica}
+	for _, name := range []string{"vault", "replica"} {
+		pol := want[name]
+		pol.PolicyType, pol.Encrypt, pol.Reptype = types.StringUnknown(), types.StringUnknown(), types.StringUnknown()
+		pol.Truncatelog, pol.Verifychoice = types.StringUnknown(), types.StringUnknown()
+		want[name] = pol
+	}
+	for name := range want {
+		if !reflect.DeepEqual(got[name], want[name]) {
+			t.Errorf("got policy %s %+v, want %+v", name, got[name], want[name])
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("got %d policies, want %d", len(got), len(want))
+	}
+
+	if got := translatePolicyBlocks(templateResourceModel{}, prior); got != nil {
+		t.Errorf("got %v without policy blocks, want nil", got)
+	}
+}
+
+func TestValidateTemplatePolicies(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	(&templateResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	policyType := schemaResp.Schema.Attributes["policies"].GetType().(types.MapType).ElemType
+	rawPolicies := func(policies map[string]templatePolicyModel) types.Map {
+		for name, pol := range policies {
+			policies[name] = withNullCollections(pol)
+		}
+		m, diags := types.MapValueFrom(ctx, policyType, policies)
+		if diags.HasError() {
+			t.Fatal(diags)
+		}
+		return m
+	}
+	weekend := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("sat"), types.StringValue("sunday")})
+
+	valid := snapshotPolicyModel{
+		Name:      types.StringValue("daily"),
+		StartTime: types.StringValue("19:00"),
+		EndTime:   types.StringValue("07:00"),
+		Retention: types.Int64Value(14),
+	}
+
+	tests := map[string]struct {
+		config   templateResourceModel
+		policies types.Map
+		want     []string
+	}{
+		"valid": {
+			config: templateResourceModel{SnapshotPolicies: []snapshotPolicyModel{valid}},
+		},
+		"unknown values": {
+			config: templateResourceModel{SnapshotPolicies: []snapshotPolicyModel{{
+				Name:      types.StringUnknown(),
+				StartTime: types.StringUnknown(),
+				EndTime:   types.StringValue("07:00"),
+				Retention: types.Int64Unknown(),
+			}}},
+		},
+		"raw policies": {
+			config:   templateResourceModel{SnapshotPolicies: []snapshotPolicyModel{valid}},
+			policies: types.MapUnknown(types.StringType),
+			want:     []string{"policies"},
+		},
+		"raw human forms": {
+			policies: rawPolicies(map[string]templatePolicyModel{"daily": {
+				StartTime: types.StringValue("19:00"),
+				EndTime:   types.StringValue("06:30"),
+				Rpo:       types.StringValue("4h"),
+				Retention: types.StringValue("30d"),
+			}}),
+		},
+		"raw invalid human forms": {
+			policies: rawPolicies(map[string]templatePolicyModel{"daily": {
+				StartTime:  types.StringValue("7pm"),
+				EndTime:    types.StringValue("06:30"),
+				Endtime:    types.StringValue("23400"),
+				Rpo:        types.StringValue("4d"),
+				Retention:  types.StringValue("30d"),
+				Retentionm: types.StringValue("days"),
+				Selection:  types.StringValue("weekday:sat"),
+			}}),
+			want: []string{
+				`policies["daily"].start_time`,
+				`policies["daily"].end_time`,
+				`policies["daily"].rpo`,
+				`policies["daily"].retentionm`,
+			},
+		},
+		"raw days": {
+			policies: func() types.Map {
+				pol := withNullCollections(templatePolicyModel{Selection: types.StringValue("weekday:sat")})
+				pol.DaysOfWeek = weekend
+				m, _ := types.MapValueFrom(ctx, policyType, map[string]templatePolicyModel{"weekend": pol})
+				return m
+			}(),
+			want: []string{
+				`policies["weekend"].days_of_week[1]`,
+				`policies["weekend"].days_of_week`,
+			},
+		},
+		"duplicate names": {
+			config: templateResourceModel{
+				SnapshotPolicies: []snapshotPolicyModel{valid},
+				OnVaultPolicies:  []onVaultPolicyModel{{Name: types.StringValue("daily"), StartTime: types.StringValue("01:00"), EndTime: types.StringValue("05:00"), Retention: types.Int64Value(8)}},
+			},
+			want: []string{"onvault_policy[0].name"},
+		},
+		"invalid values": {
+			config: templateResourceModel{
+				SnapshotPolicies: []snapshotPolicyModel{{
+					Name:          types.StringValue("daily"),
+					Priority:      types.StringValue("urgent"),
+					Schedule:      types.StringValue("hourly"),
+					StartTime:     types.StringValue("7pm"),
+					EndTime:       types.StringValue("07:00"),
+					RPOHours:      types.Int64Value(48),
+					Retention:     types.Int64Value(0),
+					RetentionUnit: types.StringValue("decades"),
+				}},
+				OnVaultPolicies: []onVaultPolicyModel{{
+					Name:      types.StringValue("vault"),
+					StartTime: types.StringValue("01:00"),
+					EndTime:   types.StringValue("05:00"),
+					Retention: types.Int64Value(8),
+					Pool:      types.Int64Value(5),
+				}},
+				ReplicationPolicies: []replicationPolicyModel{{
+					Name:       types.StringValue("replica"),
+					StartTime:  types.StringValue("01:00"),
+					EndTime:    types.StringValue("05:00"),
+					Retention:  types.Int64Value(8),
+					SourcePool: types.Int64Value(2),
+					TargetPool: types.Int64Value(2),
+				}},
+			},
+			want: []string{
+				"snapshot_policy[0].priority",
+				"snapshot_policy[0].schedule",
+				"snapshot_policy[0].start_time",
+				"snapshot_policy[0].rpo_hours",
+				"snapshot_policy[0].retention",
+				"snapshot_policy[0].retention_unit",
+				"onvault_policy[0].pool",
+				"replication_policy[0].target_pool",
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			validateTemplatePolicies(ctx, tt.config, tt.policies, &diags)
+
+			var got []string
+			for _, d := range diags {
+				if d, ok := d.(diag.DiagnosticWithPath); ok {
+					got = append(got, d.Path().String())
+				}
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("got errors at %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
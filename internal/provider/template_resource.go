@@ -5,7 +5,6 @@ package provider
 
 import (
 	"context"
-	"fmt"
 	"strconv"
 
 	"github.com/antihax/optional"
@@ -14,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -22,11 +22,24 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &templateResource{}
-	_ resource.ResourceWithConfigure   = &templateResource{}
-	_ resource.ResourceWithImportState = &templateResource{}
+	_ resource.Resource                   = &templateResource{}
+	_ resource.ResourceWithConfigure      = &templateResource{}
+	_ resource.ResourceWithImportState    = &templateResource{}
+	_ resource.ResourceWithUpgradeState   = &templateResource{}
+	_ resource.ResourceWithValidateConfig = &templateResource{}
+	_ resource.ResourceWithModifyPlan     = &templateResource{}
 )
 
+// templateConsoleFields maps the fields of template requests to the attributes
+// they are set from, so console errors are reported against them.
+var templateConsoleFields = map[string]path.Path{
+	"name":        path.Root("name"),
+	"description": path.Root("description"),
+	"sourcename":  path.Root("sourcename"),
+	"override":    path.Root("override"),
+	"policies":    path.Root("policies"),
+}
+
 // NewTemplateResource to create SLA Template
 func NewTemplateResource() resource.Resource {
 	return &templateResource{}
@@ -34,8 +47,11 @@ func NewTemplateResource() resource.Resource {
 
 // templateResource is the resource implementation.
 type templateResource struct {
-	client  *backupdr.APIClient
-	authCtx context.Context
+	client *backupdr.APIClient
+	// clientConfig makes the console requests the client has no methods
+	// for.
+	clientConfig *backupdr.Configuration
+	authCtx      context.Context
 }
 
 // Metadata returns the resource type name.
@@ -44,8 +60,9 @@ func (r *templateResource) Metadata(_ context.Context, req resource.MetadataRequ
 }
 
 // Schema defines the schema for the resource.
-func (r *templateResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *templateResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:             1,
 		MarkdownDescription: "Templates are composed of backup policies. In policies, you can define when to run a backup, how frequently to run a backup, how long to retain the backup image for (Days, Weeks, Months, Years), and also additional configuration when the policy is applied to a different application, such as a file system, database, or VM. For more information, see [Backup template](https://cloud.google.com/backup-disaster-recovery/docs/create-plan/create-template).",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -83,24 +100,39 @@ func (r *templateResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 			// },
 			"managedbyagm": schema.BoolAttribute{
 				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"usedbycloudapp": schema.BoolAttribute{
-				Optional:            true,
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 				MarkdownDescription: "It displays if the template is used by applications or not - true/false.",
 			},
 			"sourcename": schema.StringAttribute{
-				Optional:            true,
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 				MarkdownDescription: "Provide the source name. It should match the name value.",
 			},
 			"override": schema.StringAttribute{
 				Optional:            true,
 				MarkdownDescription: "Setting “Yes” will allow the policies set in this template to be overridden per-application. Setting “No” will enforce the policies as configured in this template without allowing any per-application overrides.",
 			},
-			"policies": schema.ListNestedAttribute{
+			"options": optionsAttribute("template"),
+			"policies": schema.MapNestedAttribute{
 				Optional:            true,
-				MarkdownDescription: "Provide policy details for backup template.",
+				Computed:            true,
+				MarkdownDescription: "Provide policy details for backup template, keyed by policy name, in the raw management console form. Renaming a policy deletes it and creates a policy with the new name. Prefer the `snapshot_policy`, `onvault_policy` and `replication_policy` blocks, and keep this for settings they do not cover. When the blocks are used, this displays the policies they translate to.",
 				NestedObject: schema.NestedAttributeObject{
-					Attributes: map[string]schema.Attribute{
+					Attributes: withPolicyDays(map[string]schema.Attribute{
+						"options": optionsAttribute("policy"),
 						"id": schema.StringAttribute{
 							Computed: true,
 							PlanModifiers: []planmodifier.String{
@@ -112,10 +144,6 @@ func (r *templateResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 							Optional:            true,
 							MarkdownDescription: "Provide the description for the backup policy.",
 						},
-						"name": schema.StringAttribute{
-							Required:            true,
-							MarkdownDescription: "Provide a name for the backup policy.",
-						},
 						"href": schema.StringAttribute{
 							Computed: true,
 							PlanModifiers: []planmodifier.String{
@@ -137,7 +165,7 @@ func (r *templateResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 						},
 						"rpo": schema.StringAttribute{
 							Optional:            true,
-							MarkdownDescription: "Provide how often to run policy again. 24 is once per day.",
+							MarkdownDescription: "Provide how often to run policy again, either with a unit such as `4h` or `30min`, or as a number in the unit of `rpom`. 24 is once per day.",
 						},
 						"rpom": schema.StringAttribute{
 							Optional:            true,
@@ -175,19 +203,27 @@ func (r *templateResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 							MarkdownDescription: "Specify days, days of week, month and days of month to exclude backup snapshots.",
 						},
 						"reptype": schema.StringAttribute{
-							Optional:            true,
+							Optional: true,
+							Computed: true,
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
 							MarkdownDescription: "This is used for mirror policy options.",
 						},
 						"retention": schema.StringAttribute{
 							Optional:            true,
-							MarkdownDescription: "Set how long to retain an image.",
+							MarkdownDescription: "Set how long to retain an image, either with a unit such as `30d`, `4w`, `6mo` or `1y`, or as a number in the unit of `retentionm`.",
 						},
 						"retentionm": schema.StringAttribute{
 							Optional:            true,
 							MarkdownDescription: "Set the retention in days, weeks, months, or years.",
 						},
 						"encrypt": schema.StringAttribute{
-							Optional:            true,
+							Optional: true,
+							Computed: true,
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
 							MarkdownDescription: "Provide the encryption identifier.",
 						},
 						"repeatinterval": schema.StringAttribute{
@@ -203,7 +239,11 @@ func (r *templateResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 							MarkdownDescription: "This is used for mirror policy options.",
 						},
 						"policytype": schema.StringAttribute{
-							Optional:            true,
+							Optional: true,
+							Computed: true,
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
 							MarkdownDescription: "Provide the backup policy type. It can be snapshot, direct to OnVault, OnVault replication, mirror, and OnVault policy.",
 						},
 						"op": schema.StringAttribute{
@@ -215,20 +255,44 @@ func (r *templateResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 							MarkdownDescription: "Provide the verification values as true or false.",
 						},
 						"verifychoice": schema.StringAttribute{
-							Optional:            true,
+							Optional: true,
+							Computed: true,
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
 							MarkdownDescription: "Empty value by default - to be used in future versions.",
 						},
 						"truncatelog": schema.StringAttribute{
-							Optional:            true,
+							Optional: true,
+							Computed: true,
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
 							MarkdownDescription: "Enable log truncation. This may not work as required in advanced options.",
 						},
-					},
+						"start_time": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "Provide the start time for the backup plan as `HH:MM`, for example `19:00`, instead of `starttime`.",
+						},
+						"end_time": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "Provide the end time for the backup plan as `HH:MM`, for example `06:30`, instead of `endtime`.",
+						},
+					}),
 				},
 			},
 		},
+		Blocks: templateBlocks(ctx),
 	}
 }
 
+// templateBlocks returns the timeouts block and the typed policy blocks.
+func templateBlocks(ctx context.Context) map[string]schema.Block {
+	blocks := templatePolicyBlocks()
+	blocks["timeouts"] = resourceTimeoutsBlock(ctx)
+	return blocks
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *templateResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -236,11 +300,58 @@ func (r *templateResource) Configure(_ context.Context, req resource.ConfigureRe
 	}
 
 	r.client = req.ProviderData.(*backupdrProvider).client
+	r.clientConfig = req.ProviderData.(*backupdrProvider).clientConfig
 	r.authCtx = req.ProviderData.(*backupdrProvider).authCtx
 }
 
+// ValidateConfig checks the policies and the typed policy blocks.
+func (r *templateResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var policies types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("policies"), &policies)...)
+	var config templateResourceModel
+	if !getPolicyBlocks(ctx, req.Config.GetAttribute, &config, &resp.Diagnostics) {
+		return
+	}
+	validateTemplatePolicies(ctx, config, policies, &resp.Diagnostics)
+}
+
+// ModifyPlan plans the policies the typed policy blocks translate to.
+func (r *templateResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy, and raw policies are planned as
+	// configured.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var policies types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("policies"), &policies)...)
+	if resp.Diagnostics.HasError() || !policies.IsNull() {
+		return
+	}
+	// Policies stay unknown until every block is known.
+	if !req.Config.Raw.IsFullyKnown() {
+		return
+	}
+
+	var config templateResourceModel
+	if !getPolicyBlocks(ctx, req.Config.GetAttribute, &config, &resp.Diagnostics) {
+		return
+	}
+	var prior map[string]templatePolicyModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("policies"), &prior)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("policies"), translatePolicyBlocks(config, prior))...)
+}
+
 // Create a new resource.
 func (r *templateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !checkProviderConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	// Retrieve values from plan
 	var plan templateResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -249,6 +360,14 @@ func (r *templateResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, authCtx, cancel := withTimeout(ctx, r.authCtx, createTimeout)
+	defer cancel()
+
 	reqSlt := backupdr.SltRest{
 		Name: plan.Name.ValueString(),
 		// Immutable:   plan.Immutable.ValueBool(),
@@ -257,35 +376,8 @@ func (r *templateResource) Create(ctx context.Context, req resource.CreateReques
 		Override:    plan.Override.ValueString(),
 	}
 
-	for _, pol := range plan.Policies {
-		reqSlt.Policies = append(reqSlt.Policies, backupdr.PolicyRest{
-			Name:          pol.Name.ValueString(),
-			Description:   pol.Description.ValueString(),
-			Priority:      pol.Priority.ValueString(),
-			Exclusiontype: pol.Exclusiontype.ValueString(),
-			Iscontinuous:  pol.Iscontinuous.ValueBool(),
-			Rpo:           pol.Rpo.ValueString(),
-			Rpom:          pol.Rpom.ValueString(),
-			Starttime:     pol.Starttime.ValueString(),
-			Endtime:       pol.Endtime.ValueString(),
-			Scheduletype:  pol.Scheduletype.ValueString(),
-			// Scheduling:        pol.Scheduling.ValueString(),
-			Targetvault:       int32(pol.Targetvault.ValueInt64()),
-			Sourcevault:       int32(pol.Sourcevault.ValueInt64()),
-			Selection:         pol.Selection.ValueString(),
-			Exclusion:         pol.Exclusion.ValueString(),
-			Exclusioninterval: pol.Exclusioninterval.ValueString(),
-			Retention:         pol.Retention.ValueString(),
-			Retentionm:        pol.Retentionm.ValueString(),
-			Remoteretention:   int32(pol.Remoteretention.ValueInt64()),
-			PolicyType:        pol.PolicyType.ValueString(),
-			Op:                pol.Op.ValueString(),
-			Verification:      pol.Verification.ValueBool(),
-			Repeatinterval:    pol.Repeatinterval.ValueString(),
-			Encrypt:           pol.Encrypt.ValueString(),
-			Reptype:           pol.Reptype.ValueString(),
-			Verifychoice:      pol.Verifychoice.ValueString(),
-		})
+	for _, name := range sortedPolicyNames(plan.Policies) {
+		reqSlt.Policies = append(reqSlt.Policies, newPolicyRest(name, plan.Policies[name]))
 	}
 
 	// Generate API request body from plan
@@ -294,11 +386,12 @@ func (r *templateResource) Create(ctx context.Context, req resource.CreateReques
 	}
 
 	// Create new entity
-	respObject, _, err := r.client.SLATemplateApi.CreateSlt(r.authCtx, &reqBody)
+	respObject, res, err := r.client.SLATemplateApi.CreateSlt(authCtx, &reqBody)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addConsoleError(&resp.Diagnostics,
 			"Error creating SLT",
-			"Could not create SLA Template, unexpected error: "+err.Error(),
+			"Could not create SLT Template.",
+			res, err, templateConsoleFields,
 		)
 		return
 	}
@@ -308,21 +401,33 @@ func (r *templateResource) Create(ctx context.Context, req resource.CreateReques
 	plan.Href = types.StringValue(respObject.Href)
 	plan.OptionHref = types.StringValue(respObject.OptionHref)
 	plan.PolicyHref = types.StringValue(respObject.PolicyHref)
+	// The console sets these when they are left out.
+	if plan.Sourcename.IsUnknown() {
+		plan.Sourcename = types.StringValue(respObject.Sourcename)
+	}
+	if plan.Managedbyagm.IsUnknown() {
+		plan.Managedbyagm = types.BoolValue(respObject.Managedbyagm)
+	}
+	if plan.Usedbycloudapp.IsUnknown() {
+		plan.Usedbycloudapp = types.BoolValue(respObject.Usedbycloudapp)
+	}
 
 	// response doesnot show policy details
 	sltID, _ := strconv.Atoi(respObject.Id)
-	respObjectPolicies, _, err := r.client.SLATemplateApi.ListPolicies(r.authCtx, int64(sltID))
+	respObjectPolicies, res, err := r.client.SLATemplateApi.ListPolicies(authCtx, int64(sltID))
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addConsoleError(&resp.Diagnostics,
 			"Error Reading SLT Policy",
-			"Could not read SLT policies ID: "+respObject.Id+": "+err.Error(),
+			"Could not read SLT policies ID: "+respObject.Id+".",
+			res, err, nil,
 		)
 		return
 	}
-	for i, respPol := range respObjectPolicies.Items {
-		plan.Policies[i].ID = types.StringValue(respPol.Id)
-		plan.Policies[i].Href = types.StringValue(respPol.Href)
-	}
+	matchCreatedPolicies(respObject.Id, plan.Policies, respObjectPolicies.Items, &resp.Diagnostics)
+
+	// Options are set once the template and its policies exist. The state is
+	// set even when that fails, so the created template is not lost.
+	r.syncTemplateOptions(authCtx, &plan, nil, &resp.Diagnostics)
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
@@ -334,6 +439,10 @@ func (r *templateResource) Create(ctx context.Context, req resource.CreateReques
 
 // Read resource information.
 func (r *templateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if unknownProviderConfig(ctx, r.client) {
+		return
+	}
+
 	// Get current state
 	var state templateResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -342,12 +451,26 @@ func (r *templateResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, authCtx, cancel := withTimeout(ctx, r.authCtx, readTimeout)
+	defer cancel()
+
 	// Get refreshed values for SLT
-	respObject, _, err := r.client.SLATemplateApi.GetSlt(r.authCtx, state.ID.ValueString())
+	respObject, res, err := r.client.SLATemplateApi.GetSlt(authCtx, state.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
+		if isNotFound(res, err) {
+			tflog.Warn(ctx, "SLT Template no longer exists, removing it from state", map[string]any{"id": state.ID.ValueString()})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addConsoleError(&resp.Diagnostics,
 			"Error Reading SLT Template",
-			"Could not read SLT Template with ID: "+state.ID.ValueString()+": "+err.Error(),
+			"Could not read SLT Template with ID "+state.ID.ValueString()+".",
+			res, err, nil,
 		)
 		return
 	}
@@ -357,24 +480,31 @@ func (r *templateResource) Read(ctx context.Context, req resource.ReadRequest, r
 	state.Href = types.StringValue(respObject.Href)
 	state.OptionHref = types.StringValue(respObject.OptionHref)
 	state.PolicyHref = types.StringValue(respObject.PolicyHref)
+	state.Name = types.StringValue(respObject.Name)
+	state.Description = optionalStringValue(state.Description, respObject.Description)
+	state.Sourcename = optionalStringValue(state.Sourcename, respObject.Sourcename)
+	state.Override = optionalStringValue(state.Override, respObject.Override, "false")
+	state.Managedbyagm = optionalBoolValue(state.Managedbyagm, respObject.Managedbyagm)
+	state.Usedbycloudapp = optionalBoolValue(state.Usedbycloudapp, respObject.Usedbycloudapp)
 
 	// Get refreshed values for SLT Policy
 	sltID, _ := strconv.Atoi(respObject.Id)
-	respObjectPolicies, _, err := r.client.SLATemplateApi.ListPolicies(r.authCtx, int64(sltID))
+	respObjectPolicies, res, err := r.client.SLATemplateApi.ListPolicies(authCtx, int64(sltID))
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addConsoleError(&resp.Diagnostics,
 			"Error Reading SLT Policy",
-			"Could not read SLT policies ID: "+respObject.Id+": "+err.Error(),
+			"Could not read SLT policies ID: "+respObject.Id+".",
+			res, err, nil,
 		)
 		return
 	}
-	if len(respObjectPolicies.Items) > 0 {
-		for i, respPol := range respObjectPolicies.Items {
-			if i < len(state.Policies) {
-				state.Policies[i].ID = types.StringValue(respPol.Id)
-				state.Policies[i].Href = types.StringValue(respPol.Href)
-			}
-		}
+	if !checkPolicyNames(respObject.Id, respObjectPolicies.Items, &resp.Diagnostics) {
+		return
+	}
+	state.Policies = refreshTemplatePolicies(state.Policies, respObjectPolicies.Items)
+	r.readTemplateOptions(authCtx, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// Set refreshed state
@@ -386,6 +516,10 @@ func (r *templateResource) Read(ctx context.Context, req resource.ReadRequest, r
 }
 
 func (r *templateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !checkProviderConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	// Retrieve values from plan
 	var plan templateResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -394,6 +528,14 @@ func (r *templateResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, authCtx, cancel := withTimeout(ctx, r.authCtx, updateTimeout)
+	defer cancel()
+
 	// Get current state
 	var state templateResourceModel
 	diags = req.State.Get(ctx, &state)
@@ -416,11 +558,12 @@ func (r *templateResource) Update(ctx context.Context, req resource.UpdateReques
 		Body: optional.NewInterface(reqSlt),
 	}
 
-	respObject, res, err := r.client.SLATemplateApi.UpdateSlt(r.authCtx, plan.ID.ValueString(), &reqBody)
+	respObject, res, err := r.client.SLATemplateApi.UpdateSlt(authCtx, plan.ID.ValueString(), &reqBody)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addConsoleError(&resp.Diagnostics,
 			"Error Updating SLA Template",
-			"Could not update template, unexpected error: "+err.Error(),
+			"Could not update SLT Template with ID "+plan.ID.ValueString()+".",
+			res, err, templateConsoleFields,
 		)
 		return
 	}
@@ -439,131 +582,83 @@ func (r *templateResource) Update(ctx context.Context, req resource.UpdateReques
 	plan.Href = types.StringValue(respObject.Href)
 	plan.OptionHref = types.StringValue(respObject.OptionHref)
 	plan.PolicyHref = types.StringValue(respObject.PolicyHref)
+	// The console sets these when they are left out.
+	if plan.Sourcename.IsUnknown() {
+		plan.Sourcename = types.StringValue(respObject.Sourcename)
+	}
+	if plan.Managedbyagm.IsUnknown() {
+		plan.Managedbyagm = types.BoolValue(respObject.Managedbyagm)
+	}
+	if plan.Usedbycloudapp.IsUnknown() {
+		plan.Usedbycloudapp = types.BoolValue(respObject.Usedbycloudapp)
+	}
 
-	// create SLT template policy
-	if len(plan.Policies) > len(state.Policies) {
-
-		for i, pol := range plan.Policies {
-			tflog.Info(ctx, "------------------ Update Method: Check Create policy : "+pol.ID.ValueString())
-			if pol.ID.ValueString() == "" {
-				tflog.Info(ctx, "------------------ Update Method: Created policy : "+pol.ID.ValueString())
-
-				reqPol := backupdr.PolicyRest{
-					Id:                pol.ID.ValueString(),
-					Name:              pol.Name.ValueString(),
-					Description:       pol.Description.ValueString(),
-					Priority:          pol.Priority.ValueString(),
-					Exclusiontype:     pol.Exclusiontype.ValueString(),
-					Iscontinuous:      pol.Iscontinuous.ValueBool(),
-					Rpo:               pol.Rpo.ValueString(),
-					Rpom:              pol.Rpom.ValueString(),
-					Starttime:         pol.Starttime.ValueString(),
-					Endtime:           pol.Endtime.ValueString(),
-					Targetvault:       int32(pol.Targetvault.ValueInt64()),
-					Sourcevault:       int32(pol.Targetvault.ValueInt64()),
-					Scheduletype:      pol.Scheduletype.ValueString(),
-					Selection:         pol.Selection.ValueString(),
-					Exclusion:         pol.Exclusion.ValueString(),
-					Exclusioninterval: pol.Exclusioninterval.ValueString(),
-					Retention:         pol.Retention.ValueString(),
-					Retentionm:        pol.Retentionm.ValueString(),
-					Remoteretention:   int32(pol.Remoteretention.ValueInt64()),
-					PolicyType:        pol.PolicyType.ValueString(),
-					Op:                pol.Op.ValueString(),
-					Verification:      pol.Verification.ValueBool(),
-					Repeatinterval:    pol.Repeatinterval.ValueString(),
-					Encrypt:           pol.Encrypt.ValueString(),
-					Reptype:           pol.Reptype.ValueString(),
-					Verifychoice:      pol.Verifychoice.ValueString(),
-				}
-				// Generate API request body from plan
-				reqPolBody := backupdr.SLATemplateApiCreatePolicyOpts{
-					Body: optional.NewInterface(reqPol),
-				}
-				respPol, _, err := r.client.SLATemplateApi.CreatePolicy(r.authCtx, respObject.Id, &reqPolBody)
-				if err != nil {
-					resp.Diagnostics.AddError(
-						"Error Creating SLT Policy",
-						"Could not Create SLT policies ID: "+respObject.Id+": "+err.Error(),
-					)
-					return
-				}
-				plan.Policies[i].ID = types.StringValue(respPol.Id)
-				plan.Policies[i].Href = types.StringValue(respPol.Href)
-			}
+	// Delete the policies that are no longer planned, then create the new
+	// ones and update the changed ones, matching policies by name.
+	for _, name := range sortedPolicyNames(state.Policies) {
+		if _, ok := plan.Policies[name]; ok {
+			continue
+		}
+		pol := state.Policies[name]
+		tflog.Debug(ctx, "Deleting SLT policy", map[string]any{"name": name, "id": pol.ID.ValueString()})
+		res, err := r.client.SLATemplateApi.DeletePolicy(authCtx, respObject.Id, pol.ID.ValueString())
+		if err != nil && !isNotFound(res, err) {
+			addConsoleError(&resp.Diagnostics,
+				"Error Deleting SLT Policy",
+				"Could not delete policy "+name+" of SLT Template "+respObject.Id+".",
+				res, err, nil,
+			)
+			return
 		}
-
 	}
 
-	// delete SLT template policy
-	if len(plan.Policies) < len(state.Policies) {
-		missingPolicies := findMissingPolicies(plan.Policies, state.Policies)
-		tflog.Info(ctx, "------------------ Update Method: Check Delete policy "+fmt.Sprint(len(missingPolicies)))
-		for _, pol := range missingPolicies {
-			tflog.Info(ctx, "------------------ Update Method: Deleted policy : "+pol.ID.ValueString())
-			_, err := r.client.SLATemplateApi.DeletePolicy(r.authCtx, respObject.Id, pol.ID.ValueString())
+	for _, name := range sortedPolicyNames(plan.Policies) {
+		pol := plan.Policies[name]
+		prior, exists := state.Policies[name]
+		reqPol := newPolicyRest(name, pol)
+
+		switch {
+		case !exists || prior.ID.ValueString() == "":
+			tflog.Debug(ctx, "Creating SLT policy", map[string]any{"name": name})
+			reqPolBody := backupdr.SLATemplateApiCreatePolicyOpts{
+				Body: optional.NewInterface(reqPol),
+			}
+			respPol, res, err := r.client.SLATemplateApi.CreatePolicy(authCtx, respObject.Id, &reqPolBody)
 			if err != nil {
-				resp.Diagnostics.AddError(
+				addConsoleError(&resp.Diagnostics,
 					"Error Creating SLT Policy",
-					"Could not Create SLT policies ID: "+respObject.Id+": "+err.Error(),
+					"Could not create policy "+name+" of SLT Template "+respObject.Id+".",
+					res, err, nil,
 				)
 				return
 			}
-		}
-
-	}
-
-	// update SLT template policy
-	for i, pol := range plan.Policies {
-		reqPol := backupdr.PolicyRest{
-			Id:                pol.ID.ValueString(),
-			Name:              pol.Name.ValueString(),
-			Description:       pol.Description.ValueString(),
-			Priority:          pol.Priority.ValueString(),
-			Exclusiontype:     pol.Exclusiontype.ValueString(),
-			Iscontinuous:      pol.Iscontinuous.ValueBool(),
-			Rpo:               pol.Rpo.ValueString(),
-			Rpom:              pol.Rpom.ValueString(),
-			Starttime:         pol.Starttime.ValueString(),
-			Endtime:           pol.Endtime.ValueString(),
-			Targetvault:       int32(pol.Targetvault.ValueInt64()),
-			Sourcevault:       int32(pol.Targetvault.ValueInt64()),
-			Scheduletype:      pol.Scheduletype.ValueString(),
-			Selection:         pol.Selection.ValueString(),
-			Exclusion:         pol.Exclusion.ValueString(),
-			Exclusioninterval: pol.Exclusioninterval.ValueString(),
-			Retention:         pol.Retention.ValueString(),
-			Retentionm:        pol.Retentionm.ValueString(),
-			Remoteretention:   int32(pol.Remoteretention.ValueInt64()),
-			PolicyType:        pol.PolicyType.ValueString(),
-			Op:                pol.Op.ValueString(),
-			Verification:      pol.Verification.ValueBool(),
-			Repeatinterval:    pol.Repeatinterval.ValueString(),
-			Encrypt:           pol.Encrypt.ValueString(),
-			Reptype:           pol.Reptype.ValueString(),
-			Verifychoice:      pol.Verifychoice.ValueString(),
-		}
-		// Generate API request body from plan
-		if pol.ID.ValueString() != "" {
-			tflog.Info(ctx, "------------------ Update Method: Update policy : "+pol.ID.ValueString())
+			pol = completeTemplatePolicy(pol, respPol)
+		case policyChanged(pol, prior):
+			tflog.Debug(ctx, "Updating SLT policy", map[string]any{"name": name, "id": pol.ID.ValueString()})
+			reqPol.Id = pol.ID.ValueString()
 			reqPolBody := backupdr.SLATemplateApiUpdatePolicyOpts{
 				Body: optional.NewInterface(reqPol),
 			}
-
-			// ignore if there is no diff
-
-			respPol, _, err := r.client.SLATemplateApi.UpdatePolicy(r.authCtx, respObject.Id, pol.ID.ValueString(), &reqPolBody)
+			respPol, res, err := r.client.SLATemplateApi.UpdatePolicy(authCtx, respObject.Id, pol.ID.ValueString(), &reqPolBody)
 			if err != nil {
-				resp.Diagnostics.AddError(
+				addConsoleError(&resp.Diagnostics,
 					"Error Updating SLT Policy",
-					"Could not Update SLT policies ID: "+pol.ID.ValueString()+": "+err.Error(),
+					"Could not update policy "+name+" of SLT Template "+respObject.Id+".",
+					res, err, nil,
 				)
 				return
 			}
-			plan.Policies[i].Href = types.StringValue(respPol.Href)
+			pol = completeTemplatePolicy(pol, respPol)
+		default:
+			continue
 		}
+		plan.Policies[name] = pol
 	}
 
+	// The state is set even when syncing the options fails, so the changes
+	// made so far are recorded.
+	r.syncTemplateOptions(authCtx, &plan, &state, &resp.Diagnostics)
+
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -572,6 +667,10 @@ func (r *templateResource) Update(ctx context.Context, req resource.UpdateReques
 }
 
 func (r *templateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !checkProviderConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	// Retrieve values from state
 	var state templateResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -580,37 +679,39 @@ func (r *templateResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, authCtx, cancel := withTimeout(ctx, r.authCtx, deleteTimeout)
+	defer cancel()
+
 	// Delete existing order
-	_, err := r.client.SLATemplateApi.DeleteSlt(r.authCtx, state.ID.ValueString())
+	res, err := r.client.SLATemplateApi.DeleteSlt(authCtx, state.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Deleting HashiCups Order",
-			"Could not delete order, unexpected error: "+err.Error(),
+		addConsoleError(&resp.Diagnostics,
+			"Error Deleting SLT Template",
+			"Could not delete SLT Template with ID "+state.ID.ValueString()+".",
+			res, err, nil,
 		)
 		return
 	}
 }
 
 func (r *templateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if !checkProviderConfigured(r.client, &resp.Diagnostics) {
+		return
+	}
+
 	// Retrieve import ID and save to id attribute
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
-func findMissingPolicies(list1Policies, list2Policies []policyRestModel) []policyRestModel {
-	var missingPolicies []policyRestModel
-
-	// Create a map of state policies for efficient lookup
-	list1PolicyMap := make(map[string]bool)
-	for _, statePolicy := range list1Policies {
-		list1PolicyMap[statePolicy.ID.ValueString()] = true
+// UpgradeState converts the positional policies list of version 0 into a map
+// keyed by policy name.
+func (r *templateResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {StateUpgrader: upgradeTemplatePoliciesToMap},
 	}
-
-	// Iterate through plan policies and check if they exist in the state map
-	for _, list2Policy := range list2Policies {
-		if _, ok := list1PolicyMap[list2Policy.ID.ValueString()]; !ok {
-			missingPolicies = append(missingPolicies, list2Policy)
-		}
-	}
-
-	return missingPolicies
 }
@@ -0,0 +1,344 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccTemplateResourceConfig(console *fakeConsole, description string) string {
+	return testAccProviderConfig(console) + fmt.Sprintf(`
+resource "backupdr_template" "test" {
+  name        = "test-template"
+  description = %q
+  sourcename  = "test-template"
+  override    = "false"
+  policies = {
+    "daily-snapshot" = {
+      op           = "snap"
+      priority     = "medium"
+      rpo          = "24"
+      rpom         = "24"
+      starttime    = "68400"
+      endtime      = "64800"
+      retention    = "14"
+      retentionm   = "days"
+      scheduletype = "daily"
+      selection    = "daily"
+      policytype   = "snapshot"
+    }
+  }
+}
+`, description)
+}
+
+func TestAccTemplateResource(t *testing.T) {
+	console := newFakeConsole(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             console.CheckDestroyed("slt"),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccTemplateResourceConfig(console, "first"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("backupdr_template.test", "name", "test-template"),
+					resource.TestCheckResourceAttr("backupdr_template.test", "policies.%", "1"),
+					resource.TestCheckResourceAttrSet("backupdr_template.test", "policies.daily-snapshot.id"),
+					resource.TestCheckResourceAttrSet("backupdr_template.test", "policy_href"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "backupdr_template.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccTemplateResourceConfig(console, "second"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("backupdr_template.test", "description", "second"),
+					console.CheckField("slt", "backupdr_template.test", "description", "second"),
+				),
+			},
+			// Drift testing
+			{
+				PreConfig: func() {
+					id := console.Find("slt", "name", "test-template")
+					console.Update("slt", id, map[string]any{"description": "changed in console"})
+				},
+				Config:             testAccTemplateResourceConfig(console, "second"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Policy drift testing
+			{
+				PreConfig: func() {
+					sltID := console.Find("slt", "name", "test-template")
+					policyID := console.Find("slt/"+sltID+"/policy", "name", "daily-snapshot")
+					console.Update("slt/"+sltID+"/policy", policyID, map[string]any{"retention": "30"})
+				},
+				Config:             testAccTemplateResourceConfig(console, "second"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Policy drift is corrected
+			{
+				Config: testAccTemplateResourceConfig(console, "second"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("backupdr_template.test", "policies.daily-snapshot.retention", "14"),
+				),
+			},
+			// Removed from state when deleted in the console
+			{
+				PreConfig: func() {
+					console.Delete("slt", console.Find("slt", "name", "test-template"))
+				},
+				Config:             testAccTemplateResourceConfig(console, "second"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccTemplateResourcePolicyBlocksConfig(console *fakeConsole, retention int) string {
+	return testAccProviderConfig(console) + fmt.Sprintf(`
+resource "backupdr_template" "test" {
+  name = "test-template-blocks"
+
+  snapshot_policy {
+    name       = "nightly"
+    start_time = "19:00"
+    end_time   = "07:00"
+    retention  = %d
+  }
+
+  onvault_policy {
+    name           = "vault"
+    schedule       = "weekly"
+    selection      = "sun"
+    start_time     = "01:00"
+    end_time       = "05:00"
+    retention      = 8
+    retention_unit = "weeks"
+    pool           = 2
+  }
+}
+`, retention)
+}
+
+func TestAccTemplateResource_policyBlocks(t *testing.T) {
+	console := newFakeConsole(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             console.CheckDestroyed("slt"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTemplateResourcePolicyBlocksConfig(console, 14),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("backupdr_template.test", "policies.%", "2"),
+					resource.TestCheckResourceAttrSet("backupdr_template.test", "policies.nightly.id"),
+					resource.TestCheckResourceAttr("backupdr_template.test", "policies.nightly.op", "snap"),
+					resource.TestCheckResourceAttr("backupdr_template.test", "policies.nightly.starttime", "68400"),
+					resource.TestCheckResourceAttr("backupdr_template.test", "policies.nightly.endtime", "25200"),
+					resource.TestCheckResourceAttr("backupdr_template.test", "policies.nightly.retention", "14"),
+					resource.TestCheckResourceAttr("backupdr_template.test", "policies.vault.op", "cloud"),
+					resource.TestCheckResourceAttr("backupdr_template.test", "policies.vault.targetvault", "2"),
+					resource.TestCheckResourceAttr("backupdr_template.test", "policies.vault.retentionm", "weeks"),
+				),
+			},
+			{
+				Config: testAccTemplateResourcePolicyBlocksConfig(console, 30),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("backupdr_template.test", "policies.nightly.retention", "30"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTemplateResource_humanForms(t *testing.T) {
+	console := newFakeConsole(t)
+	config := testAccProviderConfig(console) + `
+resource "backupdr_template" "test" {
+  name = "test-template-human"
+  policies = {
+    "weekend" = {
+      op           = "snap"
+      priority     = "medium"
+      start_time   = "19:00"
+      end_time     = "06:30"
+      rpo          = "4h"
+      retention    = "30d"
+      scheduletype = "weekly"
+      days_of_week = ["sat", "sun"]
+    }
+  }
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             console.CheckDestroyed("slt"),
+		Steps: []resource.TestStep{
+			// The human forms are read back as written, so the plan after
+			// the apply is empty.
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("backupdr_template.test", "policies.weekend.start_time", "19:00"),
+					resource.TestCheckResourceAttr("backupdr_template.test", "policies.weekend.rpo", "4h"),
+					resource.TestCheckResourceAttr("backupdr_template.test", "policies.weekend.retention", "30d"),
+					resource.TestCheckResourceAttr("backupdr_template.test", "policies.weekend.days_of_week.#", "2"),
+					resource.TestCheckNoResourceAttr("backupdr_template.test", "policies.weekend.starttime"),
+					resource.TestCheckNoResourceAttr("backupdr_template.test", "policies.weekend.rpom"),
+				),
+			},
+			// Console changes show up in the human form.
+			{
+				PreConfig: func() {
+					sltID := console.Find("slt", "name", "test-template-human")
+					policyID := console.Find("slt/"+sltID+"/policy", "name", "weekend")
+					console.Update("slt/"+sltID+"/policy", policyID, map[string]any{"retention": "2", "retentionm": "weeks"})
+				},
+				RefreshState: true,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("backupdr_template.test", "policies.weekend.retention", "2w"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccTemplateResourceOptionsConfig(console *fakeConsole, jobTimeout string) string {
+	return testAccProviderConfig(console) + fmt.Sprintf(`
+resource "backupdr_template" "test" {
+  name = "test-template-options"
+  options = {
+    appconsistent = "true"
+    jobtimeout    = %q
+  }
+  snapshot_policy {
+    name       = "nightly"
+    start_time = "19:00"
+    end_time   = "07:00"
+    retention  = 14
+    options = {
+      truncatelog = "true"
+    }
+  }
+}
+`, jobTimeout)
+}
+
+func TestAccTemplateResource_options(t *testing.T) {
+	console := newFakeConsole(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             console.CheckDestroyed("slt"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTemplateResourceOptionsConfig(console, "3600"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("backupdr_template.test", "options.%", "2"),
+					resource.TestCheckResourceAttr("backupdr_template.test", "options.jobtimeout", "3600"),
+					resource.TestCheckResourceAttr("backupdr_template.test", "policies.nightly.options.truncatelog", "true"),
+				),
+			},
+			{
+				Config: testAccTemplateResourceOptionsConfig(console, "7200"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("backupdr_template.test", "options.jobtimeout", "7200"),
+				),
+			},
+			// Options added in the console are read back as drift.
+			{
+				PreConfig: func() {
+					sltID := console.Find("slt", "name", "test-template-options")
+					console.Seed("slt/"+sltID+"/option", map[string]any{"name": "skiplunlevel", "value": "true"})
+				},
+				RefreshState: true,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("backupdr_template.test", "options.%", "3"),
+					resource.TestCheckResourceAttr("backupdr_template.test", "options.skiplunlevel", "true"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			// Applying the config removes them again.
+			{
+				Config: testAccTemplateResourceOptionsConfig(console, "7200"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("backupdr_template.test", "options.%", "2"),
+					resource.TestCheckNoResourceAttr("backupdr_template.test", "options.skiplunlevel"),
+				),
+			},
+		},
+	})
+}
+
+func TestTemplateResource_readKeepsConsoleDefaultsNull(t *testing.T) {
+	console := newFakeConsole(t)
+	sltID := console.Seed("slt", map[string]any{"name": "minimal"})
+	policyID := console.Seed("slt/"+sltID+"/policy", map[string]any{
+		"name":      "daily",
+		"op":        "snap",
+		"rpo":       "24",
+		"retention": "14",
+		"starttime": "68400",
+		"endtime":   "25200",
+	})
+
+	pol := emptyTemplatePolicy()
+	pol.ID = types.StringValue(policyID)
+	pol.Op = types.StringValue("snap")
+	pol.Rpo = types.StringValue("24")
+	pol.Retention = types.StringValue("14")
+	pol.Starttime = types.StringValue("68400")
+	pol.Endtime = types.StringValue("25200")
+
+	state := readResource(t, &templateResource{client: console.Client(), clientConfig: console.Config(), authCtx: context.Background()}, map[string]any{
+		"id":       types.StringValue(sltID),
+		"name":     types.StringValue("minimal"),
+		"policies": map[string]templatePolicyModel{"daily": pol},
+	})
+
+	var got templateResourceModel
+	if diags := state.Get(context.Background(), &got); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if !got.Override.IsNull() {
+		t.Errorf("got override %s, want null", got.Override)
+	}
+	refreshed := got.Policies["daily"]
+	for name, value := range map[string]types.String{
+		"priority":          refreshed.Priority,
+		"scheduletype":      refreshed.Scheduletype,
+		"selection":         refreshed.Selection,
+		"exclusion":         refreshed.Exclusion,
+		"exclusiontype":     refreshed.Exclusiontype,
+		"repeatinterval":    refreshed.Repeatinterval,
+		"exclusioninterval": refreshed.Exclusioninterval,
+		"rpom":              refreshed.Rpom,
+		"retentionm":        refreshed.Retentionm,
+	} {
+		if !value.IsNull() {
+			t.Errorf("got %s %s, want null", name, value)
+		}
+	}
+	if !refreshed.Verification.IsNull() || !refreshed.Targetvault.IsNull() {
+		t.Errorf("got verification %s and targetvault %s, want null", refreshed.Verification, refreshed.Targetvault)
+	}
+}
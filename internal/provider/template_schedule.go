@@ -0,0 +1,259 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Policies store their window as seconds since midnight, their RPO and
+// retention as a number and a unit, and the days they run on as a selection
+// string. The helpers below convert between those and the forms the template
+// resource accepts: `19:00`, `4h`, `30d` and lists of days.
+
+// policyWeekdays and policyMonths are the days of the week and the months of
+// a policy selection.
+var (
+	policyWeekdays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+	policyMonths   = []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+)
+
+// rpoUnits and retentionUnits map the suffixes of an RPO and of a retention
+// to their console units. Minutes and months have distinct suffixes so that
+// `m` cannot mean one in an RPO and the other in a retention.
+var (
+	rpoUnits       = map[string]string{"h": "hours", "min": "minutes"}
+	retentionUnits = map[string]string{"d": "days", "w": "weeks", "mo": "months", "y": "years"}
+)
+
+// unitSizes gives each console unit as a multiple of the smallest unit it
+// converts to exactly, so that durations such as `2w` and `14d` compare
+// equal. Months and days do not convert exactly.
+var unitSizes = map[string]struct {
+	base string
+	size uint64
+}{
+	"minutes": {"minutes", 1},
+	"hours":   {"minutes", 60},
+	"days":    {"days", 1},
+	"weeks":   {"days", 7},
+	"months":  {"months", 1},
+	"years":   {"months", 12},
+}
+
+// policyTime returns the seconds since midnight of an `HH:MM` time, the
+// encoding of the policy starttime and endtime.
+func policyTime(s string) (int64, error) {
+	hours, minutes, ok := strings.Cut(s, ":")
+	h, herr := strconv.Atoi(hours)
+	m, merr := strconv.Atoi(minutes)
+	if !ok || len(hours) != 2 || len(minutes) != 2 || herr != nil || merr != nil || h > 23 || m > 59 || h < 0 || m < 0 {
+		return 0, fmt.Errorf("%q is not a time of day formatted as HH:MM", s)
+	}
+	return int64(h*3600 + m*60), nil
+}
+
+// policyClock returns the `HH:MM` time of seconds since midnight, with the
+// seconds appended when they are not zero.
+func policyClock(seconds int64) string {
+	clock := fmt.Sprintf("%02d:%02d", seconds/3600, seconds%3600/60)
+	if seconds%60 != 0 {
+		clock += fmt.Sprintf(":%02d", seconds%60)
+	}
+	return clock
+}
+
+// clockValue returns the time read from the console starttime or endtime.
+// An equivalent current time is kept as written.
+func clockValue(current types.String, seconds string) types.String {
+	n, err := strconv.ParseInt(seconds, 10, 64)
+	if err != nil {
+		return types.StringValue(seconds)
+	}
+	if c, err := policyTime(current.ValueString()); err == nil && c == n {
+		return current
+	}
+	return types.StringValue(policyClock(n))
+}
+
+// parseDuration splits a duration such as `4h` into its number and the unit
+// its suffix maps to. It returns false for plain numbers and for unknown
+// suffixes.
+func parseDuration(s string, units map[string]string) (string, string, bool) {
+	i := strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' })
+	if i <= 0 {
+		return "", "", false
+	}
+	number, suffix := s[:i], s[i:]
+	unit, ok := units[suffix]
+	if n, err := strconv.ParseUint(number, 10, 32); !ok || err != nil || n == 0 {
+		return "", "", false
+	}
+	return number, unit, true
+}
+
+// isDuration reports whether s is meant as a duration with a unit suffix
+// rather than a plain number.
+func isDuration(s string) bool {
+	_, err := strconv.ParseUint(s, 10, 32)
+	return s != "" && err != nil
+}
+
+// equalDurations reports whether two console numbers and units are the same
+// duration, converting the units where they convert exactly.
+func equalDurations(number1, unit1, number2, unit2 string) bool {
+	a, err1 := strconv.ParseUint(number1, 10, 32)
+	b, err2 := strconv.ParseUint(number2, 10, 32)
+	if err1 != nil || err2 != nil {
+		return number1 == number2 && unit1 == unit2
+	}
+	s1, ok1 := unitSizes[unit1]
+	s2, ok2 := unitSizes[unit2]
+	if !ok1 || !ok2 {
+		return a == b && unit1 == unit2
+	}
+	return s1.base == s2.base && a*s1.size == b*s2.size
+}
+
+// consoleDuration returns the console number and unit of a duration
+// attribute and of the attribute holding its unit.
+func consoleDuration(value, unit types.String, units map[string]string) (string, string) {
+	if number, u, ok := parseDuration(value.ValueString(), units); ok {
+		return number, u
+	}
+	return value.ValueString(), unit.ValueString()
+}
+
+// durationValue returns the duration read from the console number and unit,
+// formatted with the suffix of the unit. An equivalent current duration, such
+// as `24h` for 1440 minutes, is kept as written. The number is returned alone
+// when the unit has no suffix.
+func durationValue(current types.String, number, unit string, units map[string]string) types.String {
+	if n, u, ok := parseDuration(current.ValueString(), units); ok && equalDurations(n, u, number, unit) {
+		return current
+	}
+	for suffix, u := range units {
+		if u == unit {
+			return types.StringValue(number + suffix)
+		}
+	}
+	return types.StringValue(number)
+}
+
+// policySelection returns the console selection of the days a policy runs
+// on, such as `weekday:sun,sat`, or an empty string when none is given.
+func policySelection(weekdays []string, monthdays []int64, months []string) string {
+	var parts []string
+	if len(weekdays) > 0 {
+		parts = append(parts, "weekday:"+strings.Join(weekdays, ","))
+	}
+	if len(monthdays) > 0 {
+		days := make([]string, len(monthdays))
+		for i, day := range monthdays {
+			days[i] = strconv.FormatInt(day, 10)
+		}
+		parts = append(parts, "monthday:"+strings.Join(days, ","))
+	}
+	if len(months) > 0 {
+		parts = append(parts, "month:"+strings.Join(months, ","))
+	}
+	return strings.Join(parts, " ")
+}
+
+// parsePolicySelection splits a console selection into the days of the
+// week, the days of the month and the months. It returns false when the
+// selection uses another form.
+func parsePolicySelection(selection string) (weekdays []string, monthdays []int64, months []string, ok bool) {
+	if selection == "" || selection == "none" {
+		return nil, nil, nil, true
+	}
+	for _, part := range strings.Fields(selection) {
+		key, values, found := strings.Cut(part, ":")
+		if !found {
+			return nil, nil, nil, false
+		}
+		for _, value := range strings.Split(values, ",") {
+			switch key {
+			case "weekday":
+				weekdays = append(weekdays, value)
+			case "month":
+				months = append(months, value)
+			case "monthday":
+				day, err := strconv.ParseInt(value, 10, 64)
+				if err != nil {
+					return nil, nil, nil, false
+				}
+				monthdays = append(monthdays, day)
+			default:
+				return nil, nil, nil, false
+			}
+		}
+	}
+	return weekdays, monthdays, months, true
+}
+
+// listStrings returns the known string elements of l.
+func listStrings(l types.List) []string {
+	var values []string
+	for _, e := range l.Elements() {
+		if s, ok := e.(types.String); ok && !s.IsNull() && !s.IsUnknown() {
+			values = append(values, s.ValueString())
+		}
+	}
+	return values
+}
+
+// listInt64s returns the known number elements of l.
+func listInt64s(l types.List) []int64 {
+	var values []int64
+	for _, e := range l.Elements() {
+		if i, ok := e.(types.Int64); ok && !i.IsNull() && !i.IsUnknown() {
+			values = append(values, i.ValueInt64())
+		}
+	}
+	return values
+}
+
+// selectorValue returns the days read from the console as a list of
+// elemType. An unset current list stays null when the console has no days,
+// and a current list holding the same days in another order is kept.
+func selectorValue[T comparable](current types.List, values []T, elemType attr.Type, value func(T) attr.Value) types.List {
+	if len(values) == 0 && current.IsNull() {
+		return types.ListNull(elemType)
+	}
+	elems := make([]attr.Value, len(values))
+	for i, v := range values {
+		elems[i] = value(v)
+	}
+	refreshed := types.ListValueMust(elemType, elems)
+	if sameElements(current.Elements(), elems) {
+		return current
+	}
+	return refreshed
+}
+
+// sameElements reports whether a and b hold the same values in any order.
+func sameElements(a, b []attr.Value) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	keys := func(values []attr.Value) []string {
+		k := make([]string, len(values))
+		for i, v := range values {
+			k[i] = v.String()
+		}
+		sort.Strings(k)
+		return k
+	}
+	ka, kb := keys(a), keys(b)
+	for i := range ka {
+		if ka[i] != kb[i] {
+			return false
+		}
+	}
+	return true
+}
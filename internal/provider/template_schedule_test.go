@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPolicyTime(t *testing.T) {
+	for s, want := range map[string]int64{"00:00": 0, "07:00": 25200, "19:00": 68400, "23:59": 86340} {
+		got, err := policyTime(s)
+		if err != nil || got != want {
+			t.Errorf("policyTime(%q) = %d, %v, want %d", s, got, err, want)
+		}
+		if clock := policyClock(want); clock != s {
+			t.Errorf("policyClock(%d) = %q, want %q", want, clock, s)
+		}
+	}
+	for _, s := range []string{"", "7:00", "24:00", "12:60", "12-00", "1900", "aa:bb"} {
+		if _, err := policyTime(s); err == nil {
+			t.Errorf("policyTime(%q) did not fail", s)
+		}
+	}
+	if clock := policyClock(68430); clock != "19:00:30" {
+		t.Errorf("policyClock(68430) = %q, want the seconds", clock)
+	}
+}
+
+func TestClockValue(t *testing.T) {
+	if got := clockValue(types.StringValue("19:00"), "68400"); got.ValueString() != "19:00" {
+		t.Errorf("got %s for an unchanged time", got)
+	}
+	if got := clockValue(types.StringValue("19:00"), "23400"); got.ValueString() != "06:30" {
+		t.Errorf("got %s for a time changed in the console, want 06:30", got)
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		in           string
+		units        map[string]string
+		number, unit string
+		ok           bool
+	}{
+		{"4h", rpoUnits, "4", "hours", true},
+		{"30min", rpoUnits, "30", "minutes", true},
+		{"30d", retentionUnits, "30", "days", true},
+		{"6mo", retentionUnits, "6", "months", true},
+		{"1y", retentionUnits, "1", "years", true},
+		{"24", rpoUnits, "", "", false},
+		{"0h", rpoUnits, "", "", false},
+		{"4d", rpoUnits, "", "", false},
+		{"h", rpoUnits, "", "", false},
+		{"-4h", rpoUnits, "", "", false},
+		{"30m", rpoUnits, "", "", false},
+		{"6m", retentionUnits, "", "", false},
+		{"6mon", retentionUnits, "", "", false},
+	}
+	for _, tt := range tests {
+		number, unit, ok := parseDuration(tt.in, tt.units)
+		if number != tt.number || unit != tt.unit || ok != tt.ok {
+			t.Errorf("parseDuration(%q) = %q, %q, %t, want %q, %q, %t", tt.in, number, unit, ok, tt.number, tt.unit, tt.ok)
+		}
+	}
+}
+
+func TestDurationValue(t *testing.T) {
+	tests := []struct {
+		current      string
+		number, unit string
+		want         string
+	}{
+		{"30d", "30", "days", "30d"},
+		{"030d", "30", "days", "030d"},
+		{"30d", "14", "days", "14d"},
+		{"30d", "4", "weeks", "4w"},
+		{"30d", "4", "fortnights", "4"},
+		{"14d", "2", "weeks", "14d"},
+		{"1y", "12", "months", "1y"},
+		{"30d", "1", "months", "1mo"},
+	}
+	for _, tt := range tests {
+		if got := durationValue(types.StringValue(tt.current), tt.number, tt.unit, retentionUnits); got.ValueString() != tt.want {
+			t.Errorf("durationValue(%q, %q, %q) = %s, want %q", tt.current, tt.number, tt.unit, got, tt.want)
+		}
+	}
+
+	if got := durationValue(types.StringValue("24h"), "1440", "minutes", rpoUnits); got.ValueString() != "24h" {
+		t.Errorf("got %s for 1440 minutes, want 24h kept", got)
+	}
+}
+
+func TestPolicySelection(t *testing.T) {
+	tests := []struct {
+		weekdays  []string
+		monthdays []int64
+		months    []string
+		selection string
+	}{
+		{nil, nil, nil, ""},
+		{[]string{"sat", "sun"}, nil, nil, "weekday:sat,sun"},
+		{nil, []int64{1, 15}, nil, "monthday:1,15"},
+		{nil, []int64{1}, []string{"jan", "jul"}, "monthday:1 month:jan,jul"},
+	}
+	for _, tt := range tests {
+		selection := policySelection(tt.weekdays, tt.monthdays, tt.months)
+		if selection != tt.selection {
+			t.Errorf("got selection %q, want %q", selection, tt.selection)
+		}
+		weekdays, monthdays, months, ok := parsePolicySelection(selection)
+		if !ok || !reflect.DeepEqual(weekdays, tt.weekdays) || !reflect.DeepEqual(monthdays, tt.monthdays) || !reflect.DeepEqual(months, tt.months) {
+			t.Errorf("parsePolicySelection(%q) = %v, %v, %v, %t", selection, weekdays, monthdays, months, ok)
+		}
+	}
+	for _, selection := range []string{"sun", "weekday", "monthday:first", "hour:1"} {
+		if _, _, _, ok := parsePolicySelection(selection); ok {
+			t.Errorf("parsePolicySelection(%q) did not fail", selection)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Default timeouts of resource operations, used when the timeouts block does
+// not set them. They bound every console call and polling loop of the
+// operation, retries included.
+const (
+	defaultCreateTimeout = 20 * time.Minute
+	defaultReadTimeout   = 5 * time.Minute
+	defaultUpdateTimeout = 20 * time.Minute
+	defaultDeleteTimeout = 20 * time.Minute
+)
+
+// resourceTimeoutsBlock returns the timeouts block of every resource.
+func resourceTimeoutsBlock(ctx context.Context) schema.Block {
+	return timeouts.Block(ctx, timeouts.Opts{
+		Create:            true,
+		Read:              true,
+		Update:            true,
+		Delete:            true,
+		CreateDescription: "Provide how long creating the resource may take as a duration, for example `30m`. Defaults to `20m`.",
+		ReadDescription:   "Provide how long refreshing the resource may take as a duration. Defaults to `5m`.",
+		UpdateDescription: "Provide how long updating the resource may take as a duration. Defaults to `20m`.",
+		DeleteDescription: "Provide how long deleting the resource may take as a duration. Defaults to `20m`.",
+	})
+}
+
+// nullTimeouts returns an unset timeouts block, for state that is not built
+// from a plan, such as on import.
+func nullTimeouts() timeouts.Value {
+	return timeouts.Value{Object: types.ObjectNull(map[string]attr.Type{
+		"create": types.StringType,
+		"read":   types.StringType,
+		"update": types.StringType,
+		"delete": types.StringType,
+	})}
+}
+
+// valuesContext carries the deadline and cancellation of one context and the
+// values of another, such as the credentials of the provider. Values the
+// other context lacks are looked up in the first one, unless isolated is set.
+type valuesContext struct {
+	context.Context
+	values   context.Context
+	isolated bool
+}
+
+// Value implements context.Context, preferring the values of c.values.
+func (c valuesContext) Value(key any) any {
+	if value := c.values.Value(key); value != nil || c.isolated {
+		return value
+	}
+	return c.Context.Value(key)
+}
+
+// withTimeout bounds ctx by the timeout of a resource operation. It returns
+// the bounded context and the context to make the operation's console calls
+// with, which also carries the credentials of authCtx.
+func withTimeout(ctx, authCtx context.Context, timeout time.Duration) (context.Context, context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return ctx, valuesContext{Context: ctx, values: authCtx}, cancel
+}
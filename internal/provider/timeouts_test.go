@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	backupdr "github.com/umeshkumhar/backupdr-client"
+)
+
+func TestWithTimeout(t *testing.T) {
+	authorization := make(chan string, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization <- r.Header.Get("Authorization")
+		<-r.Context().Done()
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := backupdr.NewConfiguration()
+	cfg.Host = srv.URL
+	client := backupdr.NewAPIClient(cfg)
+
+	authCtx := context.WithValue(context.Background(), backupdr.ContextAccessToken, "token")
+	ctx, callCtx, cancel := withTimeout(context.Background(), authCtx, 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, _, err := client.DiskPoolApi.GetDiskPool(callCtx, "1")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want the deadline to be exceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("call returned after %s", elapsed)
+	}
+	if ctx.Err() == nil {
+		t.Error("operation context is not done")
+	}
+	if got := <-authorization; got != "Bearer token" {
+		t.Errorf("got Authorization %q, want the credentials of authCtx", got)
+	}
+}
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// transportSettings holds the provider attributes that shape the connections
+// to the management console.
+type transportSettings struct {
+	CACertFile         string
+	CACertPEM          string
+	ProxyURL           string
+	InsecureSkipVerify bool
+}
+
+// newBaseTransport returns the transport that sends management console
+// requests, trusting the configured CA certificates in addition to the
+// system ones and going through the configured proxy. Without a proxy_url,
+// the proxy environment variables are honoured.
+func newBaseTransport(settings transportSettings, diags *diag.Diagnostics) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if settings.ProxyURL != "" {
+		proxy, err := url.Parse(settings.ProxyURL)
+		if err != nil || proxy.Scheme == "" || proxy.Host == "" {
+			diags.AddAttributeError(
+				path.Root("proxy_url"),
+				"Invalid BackupDR API Proxy",
+				"The proxy_url value must be an absolute URL such as \"http://proxy.example.com:3128\".",
+			)
+		} else {
+			transport.Proxy = http.ProxyURL(proxy)
+		}
+	}
+
+	if settings.CACertFile == "" && settings.CACertPEM == "" && !settings.InsecureSkipVerify {
+		return transport
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: settings.InsecureSkipVerify,
+	}
+
+	if settings.CACertFile != "" || settings.CACertPEM != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if settings.CACertFile != "" {
+			pem, err := os.ReadFile(settings.CACertFile)
+			if err != nil {
+				diags.AddAttributeError(
+					path.Root("ca_cert_file"),
+					"Invalid BackupDR API CA Certificate",
+					"Could not read the ca_cert_file: "+err.Error(),
+				)
+			} else if !pool.AppendCertsFromPEM(pem) {
+				diags.AddAttributeError(
+					path.Root("ca_cert_file"),
+					"Invalid BackupDR API CA Certificate",
+					"The ca_cert_file "+settings.CACertFile+" does not contain any PEM encoded certificate.",
+				)
+			}
+		}
+		if settings.CACertPEM != "" && !pool.AppendCertsFromPEM([]byte(settings.CACertPEM)) {
+			diags.AddAttributeError(
+				path.Root("ca_cert_pem"),
+				"Invalid BackupDR API CA Certificate",
+				"The ca_cert_pem value does not contain any PEM encoded certificate.",
+			)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	transport.TLSClientConfig = tlsConfig
+	return transport
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func TestNewBaseTransport_caCert(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(srv.Close)
+
+	caPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}))
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, []byte(caPEM), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		settings transportSettings
+		wantErr  bool
+	}{
+		"system CAs only":      {settings: transportSettings{}, wantErr: true},
+		"ca_cert_file":         {settings: transportSettings{CACertFile: caFile}},
+		"ca_cert_pem":          {settings: transportSettings{CACertPEM: caPEM}},
+		"insecure_skip_verify": {settings: transportSettings{InsecureSkipVerify: true}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			client := &http.Client{Transport: newBaseTransport(tt.settings, &diags)}
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			res, err := client.Get(srv.URL)
+			if tt.wantErr {
+				if err == nil {
+					res.Body.Close()
+					t.Fatal("expected a certificate error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			res.Body.Close()
+		})
+	}
+}
+
+func TestNewBaseTransport_invalid(t *testing.T) {
+	tests := map[string]struct {
+		settings transportSettings
+		want     path.Path
+	}{
+		"missing ca_cert_file": {settings: transportSettings{CACertFile: filepath.Join(t.TempDir(), "missing.pem")}, want: path.Root("ca_cert_file")},
+		"invalid ca_cert_pem":  {settings: transportSettings{CACertPEM: "not a certificate"}, want: path.Root("ca_cert_pem")},
+		"relative proxy_url":   {settings: transportSettings{ProxyURL: "proxy.example.com"}, want: path.Root("proxy_url")},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			newBaseTransport(tt.settings, &diags)
+			if diags.ErrorsCount() != 1 {
+				t.Fatalf("got %d errors, want 1: %v", diags.ErrorsCount(), diags)
+			}
+			withPath, ok := diags[0].(diag.DiagnosticWithPath)
+			if !ok || !withPath.Path().Equal(tt.want) {
+				t.Errorf("got diagnostic %v, want one on %s", diags[0], tt.want)
+			}
+		})
+	}
+}
+
+func TestNewBaseTransport_proxy(t *testing.T) {
+	var diags diag.Diagnostics
+	transport := newBaseTransport(transportSettings{ProxyURL: "http://proxy.example.com:3128"}, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "https://console.example.com/actifio/session", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxy, err := transport.Proxy(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if proxy == nil || proxy.Host != "proxy.example.com:3128" {
+		t.Errorf("got proxy %v", proxy)
+	}
+}
@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -106,12 +107,13 @@ type vcenterHostRest struct {
 	PkiState         types.String `tfsdk:"pki_state"`
 	Sourcecluster    types.String `tfsdk:"sourcecluster"`
 	// Sources          []vcenterHostRestRef `tfsdk:"sources"`
-	ApplianceClusterID types.String `tfsdk:"appliance_clusterid"`
-	Srcid              types.String `tfsdk:"srcid"`
-	Svcname            types.String `tfsdk:"svcname"`
-	Transport          types.String `tfsdk:"transport"`
-	Uniquename         types.String `tfsdk:"uniquename"`
-	Zone               types.String `tfsdk:"zone"`
+	ApplianceClusterID types.String   `tfsdk:"appliance_clusterid"`
+	Srcid              types.String   `tfsdk:"srcid"`
+	Svcname            types.String   `tfsdk:"svcname"`
+	Transport          types.String   `tfsdk:"transport"`
+	Uniquename         types.String   `tfsdk:"uniquename"`
+	Zone               types.String   `tfsdk:"zone"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
 }
 
 type vcenterHostRestRef struct {
@@ -144,10 +146,11 @@ type planResourceModel struct {
 	Dedupasyncoff    types.String             `tfsdk:"dedupasyncoff"`
 	Expirationoff    types.String             `tfsdk:"expirationoff"`
 	// Group            *backupdr.LogicalGroupRest `tfsdk:"group"`
-	ID       types.String `tfsdk:"id"`
-	Href     types.String `tfsdk:"href"`
-	Syncdate types.Int64  `tfsdk:"syncdate"`
-	Stale    types.Bool   `tfsdk:"stale"`
+	ID       types.String   `tfsdk:"id"`
+	Href     types.String   `tfsdk:"href"`
+	Syncdate types.Int64    `tfsdk:"syncdate"`
+	Stale    types.Bool     `tfsdk:"stale"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 type planResourceRefModel struct {
@@ -161,7 +164,31 @@ type planResourceRefModel struct {
 // #########   backupdr_template  ############
 // ###########################################
 
+// templateResourceModel is the state of a backupdr_template resource.
 type templateResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Href        types.String `tfsdk:"href"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	OptionHref  types.String `tfsdk:"option_href"`
+	PolicyHref  types.String `tfsdk:"policy_href"`
+	Sourcename  types.String `tfsdk:"sourcename"`
+	Override    types.String `tfsdk:"override"`
+	// Policies are keyed by policy name. When the typed policy blocks are
+	// used, they hold the policies the blocks translate to.
+	Policies            map[string]templatePolicyModel `tfsdk:"policies"`
+	SnapshotPolicies    []snapshotPolicyModel          `tfsdk:"snapshot_policy"`
+	OnVaultPolicies     []onVaultPolicyModel           `tfsdk:"onvault_policy"`
+	ReplicationPolicies []replicationPolicyModel       `tfsdk:"replication_policy"`
+	Options             types.Map                      `tfsdk:"options"`
+	Managedbyagm        types.Bool                     `tfsdk:"managedbyagm"`
+	Usedbycloudapp      types.Bool                     `tfsdk:"usedbycloudapp"`
+	Timeouts            timeouts.Value                 `tfsdk:"timeouts"`
+}
+
+// templateDataSourceModel is the state of a backupdr_template data source.
+// It matches templateResourceModel without the timeouts block.
+type templateDataSourceModel struct {
 	ID          types.String      `tfsdk:"id"`
 	Href        types.String      `tfsdk:"href"`
 	Name        types.String      `tfsdk:"name"`
@@ -185,6 +212,102 @@ type templateResourceRefModel struct {
 	Stale      types.Bool   `tfsdk:"stale"`
 }
 
+// templatePolicyModel is a policy of a backupdr_template resource. It matches
+// policyRestModel without the name, which is the key of the policy.
+type templatePolicyModel struct {
+	Description       types.String `tfsdk:"description"`
+	Priority          types.String `tfsdk:"priority"`
+	Rpom              types.String `tfsdk:"rpom"`
+	Rpo               types.String `tfsdk:"rpo"`
+	Exclusiontype     types.String `tfsdk:"exclusiontype"`
+	Iscontinuous      types.Bool   `tfsdk:"iscontinuous"`
+	Starttime         types.String `tfsdk:"starttime"`
+	Endtime           types.String `tfsdk:"endtime"`
+	Targetvault       types.Int64  `tfsdk:"targetvault"`
+	Sourcevault       types.Int64  `tfsdk:"sourcevault"`
+	Selection         types.String `tfsdk:"selection"`
+	Scheduletype      types.String `tfsdk:"scheduletype"`
+	Exclusion         types.String `tfsdk:"exclusion"`
+	Reptype           types.String `tfsdk:"reptype"`
+	Retention         types.String `tfsdk:"retention"`
+	Retentionm        types.String `tfsdk:"retentionm"`
+	Encrypt           types.String `tfsdk:"encrypt"`
+	Repeatinterval    types.String `tfsdk:"repeatinterval"`
+	Exclusioninterval types.String `tfsdk:"exclusioninterval"`
+	Remoteretention   types.Int64  `tfsdk:"remoteretention"`
+	PolicyType        types.String `tfsdk:"policytype"`
+	Truncatelog       types.String `tfsdk:"truncatelog"`
+	Verifychoice      types.String `tfsdk:"verifychoice"`
+	Op                types.String `tfsdk:"op"`
+	Verification      types.Bool   `tfsdk:"verification"`
+	StartTime         types.String `tfsdk:"start_time"`
+	EndTime           types.String `tfsdk:"end_time"`
+	DaysOfWeek        types.List   `tfsdk:"days_of_week"`
+	DaysOfMonth       types.List   `tfsdk:"days_of_month"`
+	Months            types.List   `tfsdk:"months"`
+	Options           types.Map    `tfsdk:"options"`
+	ID                types.String `tfsdk:"id"`
+	Href              types.String `tfsdk:"href"`
+}
+
+// snapshotPolicyModel is a snapshot_policy block of a backupdr_template.
+type snapshotPolicyModel struct {
+	Name          types.String `tfsdk:"name"`
+	Description   types.String `tfsdk:"description"`
+	Priority      types.String `tfsdk:"priority"`
+	Schedule      types.String `tfsdk:"schedule"`
+	Selection     types.String `tfsdk:"selection"`
+	StartTime     types.String `tfsdk:"start_time"`
+	EndTime       types.String `tfsdk:"end_time"`
+	RPOHours      types.Int64  `tfsdk:"rpo_hours"`
+	Retention     types.Int64  `tfsdk:"retention"`
+	RetentionUnit types.String `tfsdk:"retention_unit"`
+	DaysOfWeek    types.List   `tfsdk:"days_of_week"`
+	DaysOfMonth   types.List   `tfsdk:"days_of_month"`
+	Months        types.List   `tfsdk:"months"`
+	Options       types.Map    `tfsdk:"options"`
+}
+
+// onVaultPolicyModel is an onvault_policy block of a backupdr_template.
+type onVaultPolicyModel struct {
+	Name          types.String `tfsdk:"name"`
+	Description   types.String `tfsdk:"description"`
+	Priority      types.String `tfsdk:"priority"`
+	Schedule      types.String `tfsdk:"schedule"`
+	Selection     types.String `tfsdk:"selection"`
+	StartTime     types.String `tfsdk:"start_time"`
+	EndTime       types.String `tfsdk:"end_time"`
+	RPOHours      types.Int64  `tfsdk:"rpo_hours"`
+	Retention     types.Int64  `tfsdk:"retention"`
+	RetentionUnit types.String `tfsdk:"retention_unit"`
+	DaysOfWeek    types.List   `tfsdk:"days_of_week"`
+	DaysOfMonth   types.List   `tfsdk:"days_of_month"`
+	Months        types.List   `tfsdk:"months"`
+	Options       types.Map    `tfsdk:"options"`
+	Pool          types.Int64  `tfsdk:"pool"`
+}
+
+// replicationPolicyModel is a replication_policy block of a
+// backupdr_template.
+type replicationPolicyModel struct {
+	Name          types.String `tfsdk:"name"`
+	Description   types.String `tfsdk:"description"`
+	Priority      types.String `tfsdk:"priority"`
+	Schedule      types.String `tfsdk:"schedule"`
+	Selection     types.String `tfsdk:"selection"`
+	StartTime     types.String `tfsdk:"start_time"`
+	EndTime       types.String `tfsdk:"end_time"`
+	RPOHours      types.Int64  `tfsdk:"rpo_hours"`
+	Retention     types.Int64  `tfsdk:"retention"`
+	RetentionUnit types.String `tfsdk:"retention_unit"`
+	DaysOfWeek    types.List   `tfsdk:"days_of_week"`
+	DaysOfMonth   types.List   `tfsdk:"days_of_month"`
+	Months        types.List   `tfsdk:"months"`
+	Options       types.Map    `tfsdk:"options"`
+	SourcePool    types.Int64  `tfsdk:"source_pool"`
+	TargetPool    types.Int64  `tfsdk:"target_pool"`
+}
+
 type policyRestModel struct {
 	Description   types.String `tfsdk:"description"`
 	Name          types.String `tfsdk:"name"`
@@ -235,7 +358,37 @@ type policyRestModel struct {
 // #########   backupdr_profile   ############
 // ###########################################
 
+// profileResourceModel is the state of a backupdr_profile resource.
 type profileResourceModel struct {
+	Description     types.String `tfsdk:"description"`
+	Name            types.String `tfsdk:"name"`
+	Srcid           types.String `tfsdk:"srcid"`
+	Clusterid       types.String `tfsdk:"clusterid"`
+	Modifydate      types.Int64  `tfsdk:"modifydate"`
+	Cid             types.String `tfsdk:"cid"`
+	Performancepool types.String `tfsdk:"performancepool"`
+	//** Primarystorage  types.String           `tfsdk:"primarystorage"`
+	Remotenode types.String `tfsdk:"remotenode"`
+	// **
+	Dedupasyncnode types.String                  `tfsdk:"dedupasyncnode"`
+	Vaultpool      *profileDiskPoolResourceModel `tfsdk:"vaultpool"`
+	Vaultpool2     *profileDiskPoolResourceModel `tfsdk:"vaultpool2"`
+	Vaultpool3     *profileDiskPoolResourceModel `tfsdk:"vaultpool3"`
+	Vaultpool4     *profileDiskPoolResourceModel `tfsdk:"vaultpool4"`
+	Createdate     types.Int64                   `tfsdk:"createdate"`
+	Localnode      types.String                  `tfsdk:"localnode"`
+	// Orglist         []OrganizationRest   `tfsdk:"orglist"`
+	// CloudCredential *CloudCredentialRest `tfsdk:"cloudCredential"`
+	ID       types.String   `tfsdk:"id"`
+	Href     types.String   `tfsdk:"href"`
+	Syncdate types.Int64    `tfsdk:"syncdate"`
+	Stale    types.Bool     `tfsdk:"stale"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// profileDataSourceModel is the state of a backupdr_profile data source.
+// It matches profileResourceModel without the timeouts block.
+type profileDataSourceModel struct {
 	Description     types.String `tfsdk:"description"`
 	Name            types.String `tfsdk:"name"`
 	Srcid           types.String `tfsdk:"srcid"`
@@ -281,6 +434,7 @@ type profileDiskPoolResourceModel struct {
 // #########  backupdr_diskpool   ############
 // ###########################################
 
+// diskPoolResourceModel is the state of a backupdr_diskpool resource.
 type diskPoolResourceModel struct {
 	Name                types.String        `tfsdk:"name"`
 	Pooltype            types.String        `tfsdk:"pooltype"`
@@ -308,6 +462,38 @@ type diskPoolResourceModel struct {
 	Href                types.String        `tfsdk:"href"`
 	Syncdate            types.Int64         `tfsdk:"syncdate"`
 	Stale               types.Bool          `tfsdk:"stale"`
+	Timeouts            timeouts.Value      `tfsdk:"timeouts"`
+}
+
+// diskPoolDataSourceModel is the state of a backupdr_diskpool data source.
+// It matches diskPoolResourceModel without the timeouts block.
+type diskPoolDataSourceModel struct {
+	Name                types.String        `tfsdk:"name"`
+	Pooltype            types.String        `tfsdk:"pooltype"`
+	Cluster             *ClusterRest        `tfsdk:"cluster"`
+	ApplianceClusterID  types.String        `tfsdk:"appliance_clusterid"`
+	Properties          []keyValueRestModel `tfsdk:"properties"`
+	Vaultprops          *vaultPropsRest     `tfsdk:"vaultprops"`
+	Usedefaultsa        types.Bool          `tfsdk:"usedefaultsa"`
+	Immutable           types.Bool          `tfsdk:"immutable"`
+	Metadataonly        types.Bool          `tfsdk:"metadataonly"`
+	State               types.String        `tfsdk:"state"`
+	Srcid               types.String        `tfsdk:"srcid"`
+	Status              types.String        `tfsdk:"status"`
+	Mdiskgrp            types.String        `tfsdk:"mdiskgrp"`
+	Modifydate          types.Int64         `tfsdk:"modifydate"`
+	Warnpct             types.Int64         `tfsdk:"warnpct"`
+	Safepct             types.Int64         `tfsdk:"safepct"`
+	Udsuid              types.Int64         `tfsdk:"udsuid"`
+	FreeMb              types.Int64         `tfsdk:"free_mb"`
+	UsageMb             types.Int64         `tfsdk:"usage_mb"`
+	CapacityMb          types.Int64         `tfsdk:"capacity_mb"`
+	Pct                 types.Float64       `tfsdk:"pct"`
+	Pooltypedisplayname types.String        `tfsdk:"pooltypedisplayname"`
+	ID                  types.String        `tfsdk:"id"`
+	Href                types.String        `tfsdk:"href"`
+	Syncdate            types.Int64         `tfsdk:"syncdate"`
+	Stale               types.Bool          `tfsdk:"stale"`
 }
 
 type keyValueRestModel struct {
@@ -356,3 +542,40 @@ type ClusterRestRef struct {
 	ID   types.String `tfsdk:"id"`
 	Href types.String `tfsdk:"href"`
 }
+
+// optionalStringValue returns the console value of an optional attribute. An
+// empty value, or one of the defaults the console fills in for the field,
+// keeps the attribute null when it is not set, so leaving the attribute out
+// of the configuration does not plan a change. The console returns empty
+// strings for fields it has no value for.
+func optionalStringValue(current types.String, value string, defaults ...string) types.String {
+	if current.IsNull() {
+		if value == "" {
+			return types.StringNull()
+		}
+		for _, d := range defaults {
+			if value == d {
+				return types.StringNull()
+			}
+		}
+	}
+	return types.StringValue(value)
+}
+
+// optionalInt64Value returns the console value of an optional number. Like
+// optionalStringValue, zero keeps the attribute null when it is not set.
+func optionalInt64Value(current types.Int64, value int64) types.Int64 {
+	if value == 0 && current.IsNull() {
+		return types.Int64Null()
+	}
+	return types.Int64Value(value)
+}
+
+// optionalBoolValue returns the console value of an optional flag. Like
+// optionalStringValue, false keeps the attribute null when it is not set.
+func optionalBoolValue(current types.Bool, value bool) types.Bool {
+	if !value && current.IsNull() {
+		return types.BoolNull()
+	}
+	return types.BoolValue(value)
+}
@@ -50,6 +50,10 @@ func main() {
 
 	err := providerserver.Serve(context.Background(), provider.New(version), opts)
 
+	// Serve returns once Terraform stops the plugin; close any console
+	// sessions the provider opened before exiting.
+	provider.CloseSessions(context.Background())
+
 	if err != nil {
 		log.Fatal(err.Error())
 	}